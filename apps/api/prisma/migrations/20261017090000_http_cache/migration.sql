@@ -0,0 +1,9 @@
+-- CreateTable
+CREATE TABLE "public"."http_cache" (
+    "url" TEXT NOT NULL,
+    "etag" TEXT,
+    "last_modified" TEXT,
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    CONSTRAINT "http_cache_pkey" PRIMARY KEY ("url")
+);
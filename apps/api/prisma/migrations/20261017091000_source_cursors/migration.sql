@@ -0,0 +1,8 @@
+-- CreateTable
+CREATE TABLE "public"."source_cursors" (
+    "key" TEXT NOT NULL,
+    "newest_published_at" TIMESTAMPTZ NOT NULL,
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    CONSTRAINT "source_cursors_pkey" PRIMARY KEY ("key")
+);
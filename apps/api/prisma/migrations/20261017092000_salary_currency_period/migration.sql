@@ -0,0 +1,5 @@
+-- AlterTable
+ALTER TABLE "public"."jobs" ADD COLUMN     "salary_currency" TEXT,
+ADD COLUMN     "salary_max_usd" INTEGER,
+ADD COLUMN     "salary_min_usd" INTEGER,
+ADD COLUMN     "salary_period" TEXT;
@@ -0,0 +1,12 @@
+-- AlterTable
+ALTER TABLE "public"."jobs" ADD COLUMN     "countries" TEXT[] DEFAULT ARRAY[]::TEXT[],
+ADD COLUMN     "eligibility" TEXT[] DEFAULT ARRAY[]::TEXT[],
+ADD COLUMN     "regions" TEXT[] DEFAULT ARRAY[]::TEXT[],
+ADD COLUMN     "remote_anywhere" BOOLEAN NOT NULL DEFAULT false,
+ADD COLUMN     "utc_offset_max" DOUBLE PRECISION,
+ADD COLUMN     "utc_offset_min" DOUBLE PRECISION,
+ADD COLUMN     "workplace_type" TEXT;
+
+-- Country/region filters use array overlap (&&)
+CREATE INDEX IF NOT EXISTS "idx_jobs_countries" ON "public"."jobs" USING GIN ("countries");
+CREATE INDEX IF NOT EXISTS "idx_jobs_regions" ON "public"."jobs" USING GIN ("regions");
@@ -0,0 +1,4 @@
+-- AlterTable
+ALTER TABLE "public"."jobs" ADD COLUMN     "employment_type" TEXT,
+ADD COLUMN     "role_family" TEXT,
+ADD COLUMN     "seniority" TEXT;
@@ -0,0 +1,14 @@
+-- CreateTable
+CREATE TABLE "public"."job_tags" (
+    "job_id" TEXT NOT NULL,
+    "tag" TEXT NOT NULL,
+    "origin" TEXT NOT NULL,
+
+    CONSTRAINT "job_tags_pkey" PRIMARY KEY ("job_id","tag")
+);
+
+-- CreateIndex
+CREATE INDEX "job_tags_tag_idx" ON "public"."job_tags"("tag");
+
+-- AddForeignKey
+ALTER TABLE "public"."job_tags" ADD CONSTRAINT "job_tags_job_id_fkey" FOREIGN KEY ("job_id") REFERENCES "public"."jobs"("id") ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,36 @@
+-- AlterTable
+ALTER TABLE "public"."jobs" ADD COLUMN     "company_id" TEXT;
+
+-- CreateTable
+CREATE TABLE "public"."companies" (
+    "id" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "logo_url" TEXT,
+    "domain" TEXT,
+    "first_seen_at" TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    CONSTRAINT "companies_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateTable
+CREATE TABLE "public"."company_aliases" (
+    "alias" TEXT NOT NULL,
+    "company_id" TEXT NOT NULL,
+
+    CONSTRAINT "company_aliases_pkey" PRIMARY KEY ("alias")
+);
+
+-- CreateIndex
+CREATE INDEX "jobs_company_id_idx" ON "public"."jobs"("company_id");
+
+-- CreateIndex
+CREATE INDEX "companies_domain_idx" ON "public"."companies"("domain");
+
+-- CreateIndex
+CREATE INDEX "company_aliases_company_id_idx" ON "public"."company_aliases"("company_id");
+
+-- AddForeignKey
+ALTER TABLE "public"."jobs" ADD CONSTRAINT "jobs_company_id_fkey" FOREIGN KEY ("company_id") REFERENCES "public"."companies"("id") ON DELETE SET NULL ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "public"."company_aliases" ADD CONSTRAINT "company_aliases_company_id_fkey" FOREIGN KEY ("company_id") REFERENCES "public"."companies"("id") ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,7 @@
+-- AlterTable
+ALTER TABLE "public"."jobs" ADD COLUMN     "cluster_id" TEXT,
+ADD COLUMN     "is_duplicate" BOOLEAN NOT NULL DEFAULT false,
+ADD COLUMN     "simhash" BIGINT;
+
+-- CreateIndex
+CREATE INDEX "jobs_cluster_id_idx" ON "public"."jobs"("cluster_id");
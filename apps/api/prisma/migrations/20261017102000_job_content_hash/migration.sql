@@ -0,0 +1,3 @@
+-- AlterTable
+ALTER TABLE "public"."jobs" ADD COLUMN     "content_hash" TEXT,
+ADD COLUMN     "updated_at" TIMESTAMPTZ;
@@ -0,0 +1,4 @@
+-- AlterTable
+ALTER TABLE "public"."jobs" ADD COLUMN     "checked_at" TIMESTAMPTZ,
+ADD COLUMN     "closed_at" TIMESTAMPTZ,
+ADD COLUMN     "missed_runs" INTEGER NOT NULL DEFAULT 0;
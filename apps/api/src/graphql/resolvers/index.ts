@@ -31,6 +31,7 @@ export function getResolvers(prisma: PrismaClient): IResolvers<string, unknown>
             title: job.title,
             company: job.company,
             description: job.description,
+            descriptionMarkdown: job.description_md,
             location: job.location,
             salaryMin: job.salary_min,
             salaryMax: job.salary_max,
@@ -46,6 +46,12 @@ export const jobs = async (
 
     let edges, endCursor, hasNextPage;
 
+    // The same job on several boards shows once, as its cluster's primary copy.
+    // Filtering by source, bookmarks or tracking still finds the exact copy.
+    const hideDuplicates = !(sources && sources.length) && !bookmarked && !isTracked;
+    // Closed postings stay visible only where the user saved them
+    const hideClosed = !bookmarked && !isTracked;
+
     // ---- check if we should personalize
     const profile = ctx.userId
       ? await ctx.prisma.user_profile.findUnique({
@@ -72,7 +78,7 @@ export const jobs = async (
       }
 
       if (minSalary !== undefined) {
-        clauses.push(`j.salary_min >= $${i}`);
+        clauses.push(`j.salary_min_usd >= $${i}`);
         params.push(minSalary);
         i++;
       }
@@ -86,6 +92,12 @@ export const jobs = async (
         params.push(`%${workType}%`);
         i++;
       }
+      if (hideDuplicates) {
+        clauses.push(`j.is_duplicate = false`);
+      }
+      if (hideClosed) {
+        clauses.push(`j.closed_at IS NULL`);
+      }
       if (sources && sources.length) {
         clauses.push(`LOWER(j.source) = ANY($${i})`);
         params.push(sources.map((s: string) => s.toLowerCase()));
@@ -135,7 +147,7 @@ export const jobs = async (
         ),
         ranked AS (
           SELECT
-            j.id, j.source, j.title, j.company, j.description, j.location,
+            j.id, j.source, j.title, j.company, j.description, j.description_md, j.location,
             j.work_type, j.salary_min, j.salary_max, j.url, j.published_at,
             (100 * (1 - (j.vector <=> (SELECT "skill_vector" FROM up)))) AS fit_score
           FROM "jobs" j
@@ -187,7 +199,7 @@ export const jobs = async (
         whereClause.fit_score = { gte: minFit };
       }
       if (minSalary !== undefined) {
-        whereClause.salary_min = { gte: minSalary };
+        whereClause.salary_min_usd = { gte: minSalary };
       }
       if (location !== undefined) {
         whereClause.location = { contains: location, mode: "insensitive" };
@@ -195,6 +207,12 @@ export const jobs = async (
       if (workType !== undefined) {
         whereClause.work_type = { contains: workType, mode: "insensitive" };
       }
+      if (hideDuplicates) {
+        whereClause.is_duplicate = false;
+      }
+      if (hideClosed) {
+        whereClause.closed_at = null;
+      }
       if (normalizedSources !== undefined) {
         whereClause.source = { in: normalizedSources };
       }
@@ -237,6 +255,7 @@ export const jobs = async (
         title: job.title,
         company: job.company,
         description: job.description,
+        descriptionMarkdown: job.description_md,
         location: job.location,
         workType: job.work_type,
         salaryMin: job.salary_min,
@@ -54,6 +54,7 @@ export interface JobParent {
   title: string;
   company: string;
   description: string;
+  description_md: string | null;
   location: string | null;
   work_type: string | null;
   salary_min: number | null;
@@ -98,6 +99,7 @@ export interface JobResult {
   title: string;
   company: string;
   description: string;
+  descriptionMarkdown: string | null;
   location: string | null;
   workType: string | null;
   salaryMin: number | null;
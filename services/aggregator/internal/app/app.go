@@ -6,11 +6,13 @@ import (
 	"time"
 
 	"github.com/sanchitb23/remote-job-radar/aggregator/internal/config"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/fetch"
 	"github.com/sanchitb23/remote-job-radar/aggregator/internal/handlers"
 	"github.com/sanchitb23/remote-job-radar/aggregator/internal/logger"
 	"github.com/sanchitb23/remote-job-radar/aggregator/internal/scorer"
 	"github.com/sanchitb23/remote-job-radar/aggregator/internal/services"
 	"github.com/sanchitb23/remote-job-radar/aggregator/internal/storage"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/tags"
 	"go.uber.org/zap"
 )
 
@@ -56,8 +58,32 @@ func NewApp() (*App, error) {
 		return nil, fmt.Errorf("failed to load skills vector: %w", err)
 	}
 
+	// All sources share one rate-limited, retrying HTTP client
+	fetchClient, err := services.NewFetchClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create source HTTP client: %w", err)
+	}
+	fetch.SetHTTPClient(fetchClient)
+	fetch.SetSourceTimezones(cfg.SourceTimezones)
+
+	// Register job sources, search-based sources derive keywords from the skills profile
+	skills, err := skillsService.LoadSkills()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load skills: %w", err)
+	}
+	registry, err := services.NewSourceRegistry(cfg, skills)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register job sources: %w", err)
+	}
+	logger.Info("Registered job sources", zap.Strings("sources", registry.Names()))
+
+	taxonomy, err := tags.LoadTaxonomy(cfg.TagsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tag taxonomy: %w", err)
+	}
+
 	// Initialize job service
-	jobService := services.NewJobService(store, skillVec, cfg.FetchTimeout, cfg)
+	jobService := services.NewJobService(store, skillVec, cfg.FetchTimeout, cfg, registry, taxonomy)
 
 	// Initialize handlers
 	handlers := handlers.NewHandlers(store, jobService, cfg)
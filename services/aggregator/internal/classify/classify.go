@@ -0,0 +1,229 @@
+package classify
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// Seniority levels
+const (
+	Intern    = "intern"
+	Junior    = "junior"
+	Mid       = "mid"
+	Senior    = "senior"
+	Staff     = "staff"
+	Principal = "principal"
+	Manager   = "manager"
+)
+
+// Employment types
+const (
+	FullTime   = "full-time"
+	PartTime   = "part-time"
+	Contract   = "contract"
+	Freelance  = "freelance"
+	Internship = "internship"
+)
+
+// Role families
+const (
+	Backend   = "backend"
+	Frontend  = "frontend"
+	Fullstack = "fullstack"
+	Mobile    = "mobile"
+	Data      = "data"
+	ML        = "ml"
+	DevOps    = "devops"
+	Security  = "security"
+	QA        = "qa"
+	Design    = "design"
+	Product   = "product"
+	Support   = "support"
+	Sales     = "sales"
+	Marketing = "marketing"
+)
+
+// Job is what the classifier reads. Hint is the source's own category or
+// type field: Adzuna's category label, the first RemoteOK tag, WWR's type,
+// an ATS commitment or department.
+type Job struct {
+	Title       string
+	Description string
+	Hint        string
+}
+
+// Result holds the derived attributes, each empty when nothing gave it away
+type Result struct {
+	Seniority      string
+	EmploymentType string
+	RoleFamily     string
+}
+
+// rule maps a pattern to a value; rules are tried in order
+type rule struct {
+	re    *regexp.Regexp
+	value string
+}
+
+func r(pattern, value string) rule {
+	return rule{regexp.MustCompile(`(?i)` + pattern), value}
+}
+
+func firstMatch(rules []rule, s string) string {
+	if s == "" {
+		return ""
+	}
+	for _, rl := range rules {
+		if rl.re.MatchString(s) {
+			return rl.value
+		}
+	}
+	return ""
+}
+
+// Title words, most specific first: a "Senior Engineering Manager" manages,
+// a "Product Manager" doesn't
+var seniorityRules = []rule{
+	r(`\b(intern|internship|trainee|apprentice(ship)?|werkstudent)\b`, Intern),
+	r(`\b(engineering manager|manager,? engineering|head of|director|vp|vice president|chief \w+ officer|cto|cio|ciso|team lead(er)?|people manager)\b`, Manager),
+	r(`\b(principal|distinguished|fellow)\b`, Principal),
+	r(`\bstaff\b`, Staff),
+	r(`\b(senior|sr\.?|lead|expert)\b|(?-i:\b(III|IV)\b)`, Senior),
+	r(`\b(mid[- ]?level|mid[- ]senior|intermediate)\b|(?-i:\bII\b)`, Mid),
+	r(`\b(junior|jr\.?|entry[- ]level|graduate|new grad|associate)\b|(?-i:\bI\b)`, Junior),
+}
+
+var employmentRules = []rule{
+	r(`\b(intern(ship)?|trainee)\b`, Internship),
+	r(`\bfreelance(r)?\b`, Freelance),
+	r(`\b(contract(or)?|contract[- ]to[- ]hire|temporary|temp|fixed[- ]term)\b`, Contract),
+	r(`\bpart[- _]?time\b`, PartTime),
+	r(`\b(full[- _]?time|permanent|regular)\b`, FullTime),
+}
+
+// Descriptions mention "contract" and "full-time" in passing, so only phrases
+// that describe the position count
+var employmentDescriptionRules = []rule{
+	r(`\bfreelance (role|position|basis|project)\b`, Freelance),
+	r(`\b(contract (role|position|basis|engagement|opportunity)|contract[- ]to[- ]hire|fixed[- ]term contract)\b`, Contract),
+	r(`\bpart[- ]time (role|position|basis|job)\b`, PartTime),
+	r(`\bfull[- ]time (role|position|basis|job|employment)\b`, FullTime),
+}
+
+var familyRules = []rule{
+	r(`\bfull[- ]?stack\b`, Fullstack),
+	r(`\b(machine learning|ml|ai|deep learning|nlp|computer vision|llm)\b`, ML),
+	r(`\b(data (engineer|scientist|science|analyst|analysis|analytics|platform|warehouse)|analytics|business intelligence|bi (developer|analyst|engineer)|etl)\b`, Data),
+	r(`\b(devops|dev ops|sre|site reliability|infrastructure|platform engineer|cloud (engineer|architect)|sysadmin|system administrator|kubernetes)\b`, DevOps),
+	r(`\b(security|appsec|infosec|penetration|pentest)\b`, Security),
+	r(`\b(qa|quality assurance|test(ing)? (engineer|automation)|sdet|automation tester)\b`, QA),
+	r(`\b(ios|android|mobile|react native|flutter)\b`, Mobile),
+	r(`\b(front[- ]?end|ui (engineer|developer)|react|angular|vue(\.js)?|web developer)\b`, Frontend),
+	r(`\b(back[- ]?end|server[- ]side|api (engineer|developer)|golang|go (engineer|developer)|java|python|ruby|rails|php|node(\.js)?|scala|elixir|asp\.net|rust)\b|(^|[^\w])(c#|\.net)([^\w]|$)`, Backend), // \b can't bound "c#" or ".net"
+	r(`\b(designer|design|ux|user experience)\b`, Design),
+	r(`\b(product manager|product owner|product management|head of product|product lead)\b|^product$`, Product),
+	r(`\b(support|customer success|customer service|help ?desk)\b`, Support),
+	r(`\b(sales|account executive|business development|sdr|bdr)\b`, Sales),
+	r(`\b(marketing|seo|content writer|copywriter|social media)\b`, Marketing),
+}
+
+// Keywords that point a generic "Software Engineer" at a family
+var stackKeywords = map[string]*regexp.Regexp{
+	Frontend: regexp.MustCompile(`(?i)\b(react|angular|vue|svelte|css|html|frontend|front-end|next\.js|tailwind|redux)\b`),
+	Backend:  regexp.MustCompile(`(?i)\b(postgres(ql)?|mysql|microservices|golang|java|spring|django|rails|node\.js|kafka|grpc|rest apis?|backend|back-end)\b`),
+	Mobile:   regexp.MustCompile(`(?i)\b(ios|android|swift|kotlin|react native|flutter)\b`),
+	DevOps:   regexp.MustCompile(`(?i)\b(kubernetes|terraform|ansible|ci/cd|helm|aws|gcp|azure|observability)\b`),
+	Data:     regexp.MustCompile(`(?i)\b(spark|airflow|dbt|snowflake|bigquery|etl|data pipelines?|warehouse)\b`),
+	ML:       regexp.MustCompile(`(?i)\b(pytorch|tensorflow|machine learning|llms?|model training|mlops)\b`),
+}
+
+var (
+	engineerTitleRegex = regexp.MustCompile(`(?i)\b(engineer|developer|programmer|swe)\b`)
+	experienceRegex    = regexp.MustCompile(`(?i)(\d{1,2})\s*\+?\s*(?:-\s*\d{1,2}\s*)?(?:years?|yrs?)(?:\s+of)?(?:\s+\w+){0,4}?\s+experience`)
+)
+
+// Classify derives seniority, employment type and role family. The title is
+// trusted most, then the source hint, then the description.
+func Classify(job Job) Result {
+	var res Result
+
+	res.Seniority = firstMatch(seniorityRules, job.Title)
+	if res.Seniority == "" {
+		res.Seniority = firstMatch(seniorityRules[:1], job.Hint)
+	}
+	if res.Seniority == "" {
+		res.Seniority = seniorityFromExperience(job.Description)
+	}
+
+	res.EmploymentType = firstMatch(employmentRules, job.Hint)
+	if res.EmploymentType == "" {
+		res.EmploymentType = firstMatch(employmentRules, job.Title)
+	}
+	if res.EmploymentType == "" && res.Seniority == Intern {
+		res.EmploymentType = Internship
+	}
+	if res.EmploymentType == "" {
+		res.EmploymentType = firstMatch(employmentDescriptionRules, job.Description)
+	}
+
+	res.RoleFamily = firstMatch(familyRules, job.Title)
+	if res.RoleFamily == "" && engineerTitleRegex.MatchString(job.Title) {
+		res.RoleFamily = familyFromStack(job.Description)
+	}
+	if res.RoleFamily == "" {
+		res.RoleFamily = firstMatch(familyRules, job.Hint)
+	}
+	return res
+}
+
+// seniorityFromExperience reads the smallest "N+ years of experience" in a description
+func seniorityFromExperience(description string) string {
+	years := -1
+	for _, m := range experienceRegex.FindAllStringSubmatch(description, -1) {
+		n, err := strconv.Atoi(m[1])
+		if err != nil || n > 20 {
+			continue
+		}
+		if years < 0 || n < years {
+			years = n
+		}
+	}
+	switch {
+	case years < 0:
+		return ""
+	case years <= 1:
+		return Junior
+	case years <= 4:
+		return Mid
+	default:
+		return Senior
+	}
+}
+
+// familyFromStack picks the family whose keywords dominate the description. A
+// description that is both clearly frontend and clearly backend is fullstack.
+func familyFromStack(description string) string {
+	const minHits = 3
+
+	counts := make(map[string]int, len(stackKeywords))
+	for family, re := range stackKeywords {
+		counts[family] = len(re.FindAllStringIndex(description, -1))
+	}
+	if counts[Frontend] >= minHits && counts[Backend] >= minHits {
+		return Fullstack
+	}
+
+	best, bestCount, runnerUp := "", 0, 0
+	for _, family := range []string{Backend, Frontend, Mobile, DevOps, Data, ML} {
+		switch c := counts[family]; {
+		case c > bestCount:
+			best, bestCount, runnerUp = family, c, bestCount
+		case c > runnerUp:
+			runnerUp = c
+		}
+	}
+	if bestCount < minHits || bestCount < 2*runnerUp {
+		return ""
+	}
+	return best
+}
@@ -0,0 +1,75 @@
+package classify
+
+import "testing"
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		job  Job
+		want Result
+	}{
+		{
+			name: "senior backend from the title",
+			job:  Job{Title: "Senior Backend Engineer"},
+			want: Result{Seniority: Senior, RoleFamily: Backend},
+		},
+		{
+			name: "staff frontend with a contract hint",
+			job:  Job{Title: "Staff Frontend Developer", Hint: "Contract"},
+			want: Result{Seniority: Staff, EmploymentType: Contract, RoleFamily: Frontend},
+		},
+		{
+			name: "intern implies an internship",
+			job:  Job{Title: "Software Engineering Intern"},
+			want: Result{Seniority: Intern, EmploymentType: Internship},
+		},
+		{
+			name: "seniority from the smallest years of experience",
+			job:  Job{Title: "Product Designer", Description: "You have 3+ years of professional experience, ideally 6 years of design experience."},
+			want: Result{Seniority: Mid, RoleFamily: Design},
+		},
+		{
+			name: "family from a backend-heavy description",
+			job: Job{
+				Title:       "Software Engineer",
+				Description: "Build APIs and microservices in Go with PostgreSQL, Kafka and gRPC on our backend.",
+			},
+			want: Result{RoleFamily: Backend},
+		},
+		{
+			name: "frontend and backend stacks make fullstack",
+			job: Job{
+				Title:       "Software Engineer",
+				Description: "React, TypeScript and CSS on the frontend; Node.js APIs, PostgreSQL and microservices on the backend.",
+			},
+			want: Result{RoleFamily: Fullstack},
+		},
+		{
+			name: "c# has no word boundary after it",
+			job:  Job{Title: "C# Developer"},
+			want: Result{RoleFamily: Backend},
+		},
+		{
+			name: ".net has no word boundary before it",
+			job:  Job{Title: ".NET Engineer"},
+			want: Result{RoleFamily: Backend},
+		},
+		{
+			name: "asp.net inside a title",
+			job:  Job{Title: "Senior ASP.NET Developer"},
+			want: Result{Seniority: Senior, RoleFamily: Backend},
+		},
+		{
+			name: "nothing to go on",
+			job:  Job{Title: "Wizard of Light Bulb Moments"},
+			want: Result{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Classify(tt.job); got != tt.want {
+				t.Errorf("Classify(%+v) = %+v, want %+v", tt.job, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package company
+
+import (
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/publicsuffix"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Legal-form suffixes dropped from the normalized key, so "Stripe, Inc." and
+// "Stripe" are the same company
+var legalSuffixRegex = regexp.MustCompile(`(?i)(\s+|^)(inc|incorporated|llc|l\.l\.c|ltd|limited|corp|corporation|co|company|gmbh|ag|sa|s\.a|sas|sarl|srl|bv|b\.v|nv|plc|pty|pty ltd|oy|ab|as|aps|ug|kk|pte|pte ltd|lp|llp)\.?$`)
+
+var nonKeyRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Display returns name with whitespace collapsed, as shown to users
+func Display(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// Key normalizes a company name into the identifier companies are matched on:
+// lower-case, accents and punctuation dropped, legal suffixes and a leading
+// "The" removed ("The Stripe Company, Inc." -> "stripe"). Empty when nothing is left.
+func Key(name string) string {
+	s := strings.ToLower(Display(stripAccents(name)))
+	s = strings.TrimPrefix(s, "the ")
+
+	// Suffixes can stack: "Acme Co., Ltd."
+	for {
+		s = strings.TrimRight(s, " ,.")
+		trimmed := legalSuffixRegex.ReplaceAllString(s, "")
+		if trimmed == s || strings.TrimSpace(trimmed) == "" {
+			break
+		}
+		s = trimmed
+	}
+
+	s = strings.ReplaceAll(s, "&", " and ")
+	return strings.Trim(nonKeyRegex.ReplaceAllString(s, "-"), "-")
+}
+
+func stripAccents(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	out, _, err := transform.String(t, s)
+	if err != nil {
+		return s
+	}
+	return out
+}
+
+// Hosts of job boards and applicant tracking systems; a link there says
+// nothing about the company's own website
+var jobHosts = []string{
+	"remoteok.com", "remoteok.io", "remotive.com", "remotive.io", "weworkremotely.com",
+	"jobspresso.co", "remote.co", "workingnomads.com", "jooble.org", "adzuna.com",
+	"arbeitnow.com", "jobicy.com",
+	"himalayas.app", "ycombinator.com", "linkedin.com", "indeed.com", "glassdoor.com",
+	"lever.co", "greenhouse.io", "ashbyhq.com", "workable.com", "smartrecruiters.com",
+	"bamboohr.com", "breezy.hr", "recruitee.com", "personio.de", "personio.com",
+	"teamtailor.com", "workday.com", "myworkdayjobs.com", "jobvite.com", "icims.com",
+	"wellfound.com", "angel.co", "notion.site", "google.com", "forms.gle", "typeform.com",
+}
+
+// Domain returns the registrable domain (eTLD+1) of a company website URL
+// ("https://jobs.stripe.com/roles" -> "stripe.com", "https://acme.co.uk" ->
+// "acme.co.uk"), empty for job boards, ATS pages, IPs and anything unparseable
+func Domain(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" || strings.HasPrefix(rawURL, "mailto:") {
+		return ""
+	}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if !strings.Contains(host, ".") || net.ParseIP(host) != nil {
+		return ""
+	}
+	for _, jh := range jobHosts {
+		if host == jh || strings.HasSuffix(host, "."+jh) {
+			return ""
+		}
+	}
+	domain, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		return ""
+	}
+	return domain
+}
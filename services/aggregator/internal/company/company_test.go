@@ -0,0 +1,46 @@
+package company
+
+import "testing"
+
+func TestKey(t *testing.T) {
+	for in, want := range map[string]string{
+		"Stripe":                   "stripe",
+		"Stripe, Inc.":             "stripe",
+		"The Stripe Company, Inc.": "stripe",
+		"Acme Co., Ltd.":           "acme",
+		"  Acme   GmbH ":           "acme",
+		"Société Générale S.A.":    "societe-generale",
+		"Procter & Gamble":         "procter-and-gamble",
+		"AT&T":                     "at-and-t",
+		"Company":                  "company", // nothing left once the suffix goes
+		"Inc.":                     "inc",
+		"":                         "",
+		"!!!":                      "",
+	} {
+		if got := Key(in); got != want {
+			t.Errorf("Key(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDomain(t *testing.T) {
+	for in, want := range map[string]string{
+		"https://www.stripe.com/jobs":       "stripe.com",
+		"https://jobs.stripe.com/roles/123": "stripe.com",
+		"acme.co.uk":                        "acme.co.uk",
+		"https://careers.acme.co.uk/":       "acme.co.uk",
+		"https://acme.github.io":            "acme.github.io",
+		"HTTPS://WWW.Stripe.COM.":           "stripe.com",
+		"https://boards.greenhouse.io/acme": "",
+		"https://jobs.lever.co/acme/123":    "",
+		"https://weworkremotely.com/jobs/1": "",
+		"mailto:jobs@acme.com":              "",
+		"http://10.0.0.1/careers":           "",
+		"localhost":                         "",
+		"":                                  "",
+	} {
+		if got := Domain(in); got != want {
+			t.Errorf("Domain(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -26,18 +28,69 @@ type Config struct {
 	AdzunaBaseURL     string
 	AdzunaAppID       string
 	AdzunaAppKey      string
+	AdzunaCountries   []string // Adzuna country indexes to search, e.g. "us", "gb"
+	AdzunaWhat        string   // keywords that must all match
+	AdzunaWhatOr      string   // keywords of which any may match, defaults to the skills profile
+	AdzunaWhere       string
+	AdzunaMaxDaysOld  int
 	JoobleAPIKey      string
 	JoobleConcurrency int
 	JoobleTimeout     time.Duration
+	JoobleQueriesFile string // Jooble query plan YAML, re-read on every fetch
 	RemotiveBaseURL   string
 	FetcherMaxPageNum int
 
+	// ATS Job Boards
+	GreenhouseBaseURL string
+	GreenhouseBoards  []string // Greenhouse board tokens, e.g. "stripe"
+	LeverBaseURL      string
+	LeverCompanies    []string // Lever company slugs, e.g. "netflix"
+	AshbyBaseURL      string
+	AshbyBoards       []string // Ashby job board names, e.g. "linear"
+	WorkableBaseURL   string
+	WorkableAccounts  []string // Workable account subdomains
+
+	// Hacker News "Who is hiring?"
+	HNBaseURL  string
+	HNThreadID int64 // 0 picks the latest thread
+
+	// Declarative RSS/Atom feeds and JSON list sources
+	FeedsFile       string
+	JSONSourcesFile string
+
 	// Skills
 	SkillsFile string
 
+	// Tag taxonomy YAML, empty uses the built-in one
+	TagsFile string
+
+	// Job expiration
+	ExpiryMissedRuns       int           // full-list runs a job may be missing from before it's closed
+	ExpiryLivenessBatch    int           // posting URLs checked per run, 0 disables
+	ExpiryLivenessInterval time.Duration // minimum time between checks of one job
+	ExpiryLivenessTimeout  time.Duration // time limit for a run's liveness checks
+
 	// Fetch Configuration
 	FetchTimeout time.Duration
 
+	// Shared source HTTP client
+	FetchUserAgent      string
+	FetchRequestTimeout time.Duration // per request attempt
+	FetchMaxRetries     int
+	FetchRetryBaseDelay time.Duration
+	FetchRetryMaxDelay  time.Duration
+	FetchRateLimit      float64            // default requests/second per host, 0 disables
+	FetchRateBurst      int                // requests allowed back to back per host
+	FetchHostRateLimits map[string]float64 // per-host overrides, e.g. "remoteok.com=0.5"
+	FetchHTTPMode       string             // live, record or replay
+	FetchFixturesDir    string             // where record writes and replay reads source responses
+
+	// Timezone of zone-less source timestamps per jobs.source, e.g. "remotive=UTC"
+	SourceTimezones map[string]*time.Location
+
+	// Salary normalization
+	SalaryFXRates map[string]float64 // US dollars per unit, overrides the built-in table
+
 	// Embedder Configuration
 	EmbedderMaxRetries     int
 	EmbedderBaseDelay      time.Duration
@@ -81,14 +134,65 @@ func Load() (*Config, error) {
 		AdzunaBaseURL:     os.Getenv("ADZUNA_BASE_URL"),
 		AdzunaAppID:       os.Getenv("ADZUNA_APP_ID"),
 		AdzunaAppKey:      os.Getenv("ADZUNA_APP_KEY"),
+		AdzunaCountries:   getListEnvWithDefault("ADZUNA_COUNTRIES", []string{"us"}),
+		AdzunaWhat:        getEnvWithDefault("ADZUNA_WHAT", "remote"),
+		AdzunaWhatOr:      os.Getenv("ADZUNA_WHAT_OR"),
+		AdzunaWhere:       os.Getenv("ADZUNA_WHERE"),
+		AdzunaMaxDaysOld:  getIntEnvWithDefault("ADZUNA_MAX_DAYS_OLD", 14),
 		JoobleAPIKey:      os.Getenv("JOOBLE_API_KEY"),
 		JoobleConcurrency: getIntEnvWithDefault("JOOBLE_CONCURRENCY", 3),
 		JoobleTimeout:     getDurationWithDefault("JOOBLE_TIMEOUT", 5*time.Minute),
+		JoobleQueriesFile: os.Getenv("JOOBLE_QUERIES_FILE"),
 		FetcherMaxPageNum: getIntEnvWithDefault("FETCHER_MAX_PAGE_NUM", 3),
 
+		// ATS Job Boards (optional)
+		GreenhouseBaseURL: os.Getenv("GREENHOUSE_BASE_URL"),
+		GreenhouseBoards:  getListEnv("GREENHOUSE_BOARDS"),
+		LeverBaseURL:      os.Getenv("LEVER_BASE_URL"),
+		LeverCompanies:    getListEnv("LEVER_COMPANIES"),
+		AshbyBaseURL:      os.Getenv("ASHBY_BASE_URL"),
+		AshbyBoards:       getListEnv("ASHBY_BOARDS"),
+		WorkableBaseURL:   os.Getenv("WORKABLE_BASE_URL"),
+		WorkableAccounts:  getListEnv("WORKABLE_ACCOUNTS"),
+
+		// Hacker News "Who is hiring?" (optional)
+		HNBaseURL:  os.Getenv("HN_BASE_URL"),
+		HNThreadID: int64(getIntEnvWithDefault("HN_WHO_IS_HIRING_THREAD_ID", 0)),
+
+		// Declarative RSS/Atom feeds and JSON list sources (optional)
+		FeedsFile:       os.Getenv("FEEDS_FILE"),
+		JSONSourcesFile: os.Getenv("JSON_SOURCES_FILE"),
+
 		// Fetch Configuration
 		FetchTimeout: getDurationWithDefault("FETCH_TIMEOUT", 5*time.Minute),
 
+		// Shared source HTTP client
+		FetchUserAgent:      getEnvWithDefault("FETCH_USER_AGENT", "remote-job-radar-aggregator/1.0"),
+		FetchRequestTimeout: getDurationWithDefault("FETCH_REQUEST_TIMEOUT", 30*time.Second),
+		FetchMaxRetries:     getIntEnvWithDefault("FETCH_MAX_RETRIES", 3),
+		FetchRetryBaseDelay: getDurationWithDefault("FETCH_RETRY_BASE_DELAY", 1*time.Second),
+		FetchRetryMaxDelay:  getDurationWithDefault("FETCH_RETRY_MAX_DELAY", 30*time.Second),
+		FetchRateLimit:      getFloatEnvWithDefault("FETCH_RATE_LIMIT", 2),
+		FetchRateBurst:      getIntEnvWithDefault("FETCH_RATE_BURST", 5),
+		FetchHostRateLimits: getRateMapEnv("FETCH_HOST_RATE_LIMITS"),
+		FetchHTTPMode:       strings.ToLower(getEnvWithDefault("FETCH_HTTP_MODE", "live")),
+		FetchFixturesDir:    getEnvWithDefault("FETCH_FIXTURES_DIR", "fixtures"),
+
+		// Publish date parsing
+		SourceTimezones: getTimezoneMapEnv("SOURCE_TIMEZONES"),
+
+		// Salary normalization
+		SalaryFXRates: getCurrencyRatesEnv("SALARY_FX_RATES"),
+
+		// Tag extraction
+		TagsFile: os.Getenv("TAGS_FILE"),
+
+		// Job expiration
+		ExpiryMissedRuns:       getIntEnvWithDefault("EXPIRY_MISSED_RUNS", 2),
+		ExpiryLivenessBatch:    getIntEnvWithDefault("EXPIRY_LIVENESS_BATCH", 0),
+		ExpiryLivenessInterval: getDurationWithDefault("EXPIRY_LIVENESS_INTERVAL", 24*time.Hour),
+		ExpiryLivenessTimeout:  getDurationWithDefault("EXPIRY_LIVENESS_TIMEOUT", 5*time.Minute),
+
 		// Embedder Configuration
 		EmbedderMaxRetries:     getIntEnvWithDefault("EMBEDDER_MAX_RETRIES", 10),
 		EmbedderBaseDelay:      getDurationWithDefault("EMBEDDER_BASE_DELAY", 1*time.Second),
@@ -103,11 +207,24 @@ func Load() (*Config, error) {
 		CronSecret:          getEnvWithDefault("CRON_SECRET", ""),
 	}
 
+	// 0 would close every job missing from a single run
+	if cfg.ExpiryMissedRuns < 1 {
+		return nil, fmt.Errorf("EXPIRY_MISSED_RUNS must be at least 1, got %d", cfg.ExpiryMissedRuns)
+	}
+
 	logger.Info("Configuration loaded successfully",
 		zap.String("port", cfg.Port),
 		zap.String("environment", cfg.Environment),
 		zap.Bool("adzunaEnabled", cfg.AdzunaAppID != "" && cfg.AdzunaAppKey != ""),
+		zap.Strings("adzunaCountries", cfg.AdzunaCountries),
 		zap.Bool("joobleEnabled", cfg.JoobleAPIKey != ""),
+		zap.Int("greenhouseBoards", len(cfg.GreenhouseBoards)),
+		zap.Int("leverCompanies", len(cfg.LeverCompanies)),
+		zap.Int("ashbyBoards", len(cfg.AshbyBoards)),
+		zap.Int("workableAccounts", len(cfg.WorkableAccounts)),
+		zap.Float64("fetchRateLimit", cfg.FetchRateLimit),
+		zap.Int("fetchMaxRetries", cfg.FetchMaxRetries),
+		zap.String("fetchHTTPMode", cfg.FetchHTTPMode),
 		zap.Int("embedderMaxRetries", cfg.EmbedderMaxRetries),
 		zap.Duration("embedderRequestTimeout", cfg.EmbedderRequestTimeout),
 		zap.Int("embedderWorkerCount", cfg.EmbedderWorkerCount),
@@ -170,6 +287,92 @@ func getIntEnvWithDefault(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getFloatEnvWithDefault returns the float value of an environment variable, or the default if unset or invalid
+func getFloatEnvWithDefault(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+		return parsed
+	}
+	logger.Warn("Invalid float format, using default",
+		zap.String("key", key),
+		zap.String("value", value),
+		zap.Float64("default", defaultValue))
+	return defaultValue
+}
+
+// getRateMapEnv parses "host=rate" pairs from a comma-separated environment variable
+func getRateMapEnv(key string) map[string]float64 {
+	rates := make(map[string]float64)
+	for _, item := range getListEnv(key) {
+		host, value, ok := strings.Cut(item, "=")
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if !ok || err != nil {
+			logger.Warn("Invalid host rate limit, ignoring",
+				zap.String("key", key),
+				zap.String("value", item))
+			continue
+		}
+		rates[strings.ToLower(strings.TrimSpace(host))] = rate
+	}
+	return rates
+}
+
+// getCurrencyRatesEnv parses "CODE=rate" pairs from a comma-separated environment variable
+func getCurrencyRatesEnv(key string) map[string]float64 {
+	rates := make(map[string]float64)
+	for _, item := range getListEnv(key) {
+		code, value, ok := strings.Cut(item, "=")
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if !ok || err != nil || rate <= 0 {
+			logger.Warn("Invalid FX rate, ignoring",
+				zap.String("key", key),
+				zap.String("value", item))
+			continue
+		}
+		rates[strings.ToUpper(strings.TrimSpace(code))] = rate
+	}
+	return rates
+}
+
+// getTimezoneMapEnv parses "source=IANA zone" pairs from a comma-separated environment variable
+func getTimezoneMapEnv(key string) map[string]*time.Location {
+	zones := make(map[string]*time.Location)
+	for _, item := range getListEnv(key) {
+		source, name, ok := strings.Cut(item, "=")
+		loc, err := time.LoadLocation(strings.TrimSpace(name))
+		if !ok || err != nil {
+			logger.Warn("Invalid source timezone, ignoring",
+				zap.String("key", key),
+				zap.String("value", item))
+			continue
+		}
+		zones[strings.ToLower(strings.TrimSpace(source))] = loc
+	}
+	return zones
+}
+
+// getListEnv splits a comma-separated environment variable, dropping empty entries
+func getListEnv(key string) []string {
+	var out []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			out = append(out, trimmed)
+		}
+	}
+	return out
+}
+
+// getListEnvWithDefault returns the comma-separated list in an environment variable, or the default if unset
+func getListEnvWithDefault(key string, defaultValue []string) []string {
+	if list := getListEnv(key); len(list) > 0 {
+		return list
+	}
+	return defaultValue
+}
+
 // IsAdzunaEnabled returns true if Adzuna API credentials are configured
 func (c *Config) IsAdzunaEnabled() bool {
 	return c.AdzunaAppID != "" && c.AdzunaAppKey != ""
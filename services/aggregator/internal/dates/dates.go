@@ -0,0 +1,89 @@
+package dates
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseError is a timestamp none of the known layouts matched
+type ParseError struct {
+	Value string
+}
+
+func (e *ParseError) Error() string { return fmt.Sprintf("unrecognized timestamp %q", e.Value) }
+
+// Layouts that carry their own offset
+var zonedLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700", // offset without colon
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999 -0700",
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700", // RSS with a single-digit day
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	time.RFC822Z,
+	time.RFC822,
+}
+
+// Layouts without an offset, read in the source's timezone
+var localLayouts = []string{
+	"2006-01-02T15:04:05.999999999", // Remotive, Jooble
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02",
+	"Jan 2, 2006",
+	"January 2, 2006",
+	"2 Jan 2006",
+	"2 January 2006",
+}
+
+// Parse reads a timestamp in any format the sources emit: RFC3339 with or
+// without fractional seconds or colon in the offset, RSS/RFC1123 dates, unix
+// seconds (10 digits) or milliseconds (13 digits), and zone-less dates and
+// times such as "2026-10-09" or "20261009", which are taken to be in loc (UTC
+// when nil). The result is in UTC.
+func Parse(s string, loc *time.Location) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, &ParseError{Value: s}
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	// Bare digits go by length, so a compact date isn't read as 1970 seconds
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil && n > 0 {
+		switch len(s) {
+		case 8:
+			if t, err := time.ParseInLocation("20060102", s, loc); err == nil {
+				return t.UTC(), nil
+			}
+		case 10, 13:
+			return Unix(n), nil
+		}
+		return time.Time{}, &ParseError{Value: s}
+	}
+	for _, layout := range zonedLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	for _, layout := range localLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, &ParseError{Value: s}
+}
+
+// Unix reads unix seconds or milliseconds, telling them apart by magnitude
+func Unix(n int64) time.Time {
+	if n > 1e12 {
+		return time.UnixMilli(n).UTC()
+	}
+	return time.Unix(n, 0).UTC()
+}
@@ -0,0 +1,59 @@
+package dates
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("no tzdata:", err)
+	}
+
+	tests := []struct {
+		in   string
+		loc  *time.Location
+		want time.Time
+	}{
+		{"2026-10-09T08:53:20Z", nil, time.Date(2026, 10, 9, 8, 53, 20, 0, time.UTC)},
+		{"2026-10-09T10:53:20.123+02:00", nil, time.Date(2026, 10, 9, 8, 53, 20, 123e6, time.UTC)},
+		{"2026-10-09T10:53:20+0200", nil, time.Date(2026, 10, 9, 8, 53, 20, 0, time.UTC)},
+		{"2026-10-09 08:53:20 +0000 UTC", nil, time.Date(2026, 10, 9, 8, 53, 20, 0, time.UTC)},
+		{"Fri, 09 Oct 2026 08:53:20 +0000", nil, time.Date(2026, 10, 9, 8, 53, 20, 0, time.UTC)},
+		{"Fri, 9 Oct 2026 10:53:20 +0200", nil, time.Date(2026, 10, 9, 8, 53, 20, 0, time.UTC)},
+		{"1760000000", nil, time.Unix(1760000000, 0).UTC()},
+		{"1760000000123", nil, time.UnixMilli(1760000000123).UTC()},
+		{"20250115", nil, time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"20250115", berlin, time.Date(2025, 1, 14, 23, 0, 0, 0, time.UTC)},
+		// Zone-less values are read in loc
+		{"2026-10-09T08:53:20.0000000", nil, time.Date(2026, 10, 9, 8, 53, 20, 0, time.UTC)},
+		{"2026-10-09T08:53:20", berlin, time.Date(2026, 10, 9, 6, 53, 20, 0, time.UTC)},
+		{"2026-10-09", berlin, time.Date(2026, 10, 8, 22, 0, 0, 0, time.UTC)},
+		{"Oct 9, 2026", nil, time.Date(2026, 10, 9, 0, 0, 0, 0, time.UTC)},
+		{"9 October 2026", nil, time.Date(2026, 10, 9, 0, 0, 0, 0, time.UTC)},
+		{"  2026-10-09  ", nil, time.Date(2026, 10, 9, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in, tt.loc)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) || got.Location() != time.UTC {
+			t.Errorf("Parse(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	// The last three are digits that are neither YYYYMMDD nor unix seconds or milliseconds
+	for _, in := range []string{"", "yesterday", "2026-13-45", "0", "-5", "20251345", "12345", "176000000012"} {
+		_, err := Parse(in, nil)
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("Parse(%q) error = %v, want a *ParseError", in, err)
+		}
+	}
+}
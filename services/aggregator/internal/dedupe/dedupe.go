@@ -0,0 +1,239 @@
+package dedupe
+
+import (
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Thresholds for treating two postings of the same company as one job
+const (
+	// Titles must share at least this much (token Jaccard) to be compared at all
+	minTitleSimilarity = 0.5
+	// Near-identical descriptions, whatever the titles say beyond the minimum
+	maxSimHashDistance = 3
+	// Same title with similar descriptions or embeddings
+	sameTitleSimilarity  = 0.8
+	looseSimHashDistance = 12
+	sameTitleCosine      = 0.9
+	// Embeddings this close are the same posting reworded by the source
+	minCosine = 0.97
+)
+
+// Candidate is a stored job as the clustering sees it
+type Candidate struct {
+	ID, Source, CompanyID, Title string
+	SimHash                      uint64 // 0 when there's no description
+	Vector                       []float32
+	// Fields that make one copy richer than another
+	DescriptionLength int
+	HasSalary         bool
+	HasLocation       bool
+	TagCount          int
+	PublishedAt       time.Time
+}
+
+var (
+	tokenRegex = regexp.MustCompile(`[\p{L}\p{N}+#]+`)
+	// Words that vary between copies of the same posting
+	titleNoise = map[string]bool{
+		"remote": true, "m": true, "f": true, "d": true, "w": true, "x": true,
+		"h": true, "all": true, "genders": true, "the": true, "a": true, "an": true,
+		"and": true, "of": true, "for": true, "in": true, "at": true, "with": true,
+	}
+	titleSynonyms = map[string]string{
+		"sr": "senior", "jr": "junior", "eng": "engineer", "engineering": "engineer",
+		"dev": "developer", "swe": "software engineer", "mgr": "manager",
+		"frontend": "front end", "backend": "back end", "fullstack": "full stack",
+	}
+)
+
+// titleTokens lower-cases a title into its meaningful words, expanding abbreviations
+func titleTokens(title string) map[string]bool {
+	tokens := make(map[string]bool)
+	for _, tok := range tokenRegex.FindAllString(strings.ToLower(title), -1) {
+		if syn, ok := titleSynonyms[tok]; ok {
+			for _, s := range strings.Fields(syn) {
+				tokens[s] = true
+			}
+			continue
+		}
+		if !titleNoise[tok] {
+			tokens[tok] = true
+		}
+	}
+	return tokens
+}
+
+// TitleSimilarity is the Jaccard similarity of two titles' word sets, 0 to 1
+func TitleSimilarity(a, b string) float64 {
+	ta, tb := titleTokens(a), titleTokens(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	shared := 0
+	for tok := range ta {
+		if tb[tok] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+// SimHash fingerprints text from its three-word shingles; copies of a
+// description that differ in boilerplate end up a few bits apart
+func SimHash(text string) uint64 {
+	words := tokenRegex.FindAllString(strings.ToLower(text), -1)
+	if len(words) == 0 {
+		return 0
+	}
+
+	const shingle = 3
+	var weights [64]int
+	for i := 0; i < max(len(words)-shingle+1, 1); i++ {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(strings.Join(words[i:min(i+shingle, len(words))], " ")))
+		sum := h.Sum64()
+		for bit := 0; bit < 64; bit++ {
+			if sum&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	var fp uint64
+	for bit, w := range weights {
+		if w > 0 {
+			fp |= 1 << bit
+		}
+	}
+	return fp
+}
+
+// Cosine is the cosine similarity of two embeddings, 0 when either is missing
+func Cosine(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+// IsDuplicate reports whether a and b are the same job posted on two sources.
+// Only postings of the same company on different sources are considered.
+func IsDuplicate(a, b Candidate) bool {
+	if a.CompanyID == "" || a.CompanyID != b.CompanyID || a.Source == b.Source {
+		return false
+	}
+	titleSim := TitleSimilarity(a.Title, b.Title)
+	if titleSim < minTitleSimilarity {
+		return false
+	}
+
+	distance := -1
+	if a.SimHash != 0 && b.SimHash != 0 {
+		distance = bits.OnesCount64(a.SimHash ^ b.SimHash)
+	}
+	cosine := Cosine(a.Vector, b.Vector)
+
+	switch {
+	case distance >= 0 && distance <= maxSimHashDistance:
+		return true
+	case titleSim >= sameTitleSimilarity &&
+		((distance >= 0 && distance <= looseSimHashDistance) || cosine >= sameTitleCosine):
+		return true
+	default:
+		return cosine >= minCosine
+	}
+}
+
+// richness ranks copies of a job: the longest description wins, with salary,
+// location and tags worth a few paragraphs each
+func richness(c Candidate) int {
+	score := c.DescriptionLength + 300*c.TagCount
+	if c.HasSalary {
+		score += 1000
+	}
+	if c.HasLocation {
+		score += 500
+	}
+	return score
+}
+
+// Cluster groups duplicates and returns, for every job in a cluster of two or
+// more, the id of the cluster's primary (richest) job. Jobs without duplicates
+// are left out.
+func Cluster(cands []Candidate) map[string]string {
+	parent := make([]int, len(cands))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	// Only postings of the same company are compared
+	byCompany := make(map[string][]int)
+	for i, c := range cands {
+		if c.CompanyID != "" {
+			byCompany[c.CompanyID] = append(byCompany[c.CompanyID], i)
+		}
+	}
+	for _, idx := range byCompany {
+		for x := 0; x < len(idx); x++ {
+			for y := x + 1; y < len(idx); y++ {
+				i, j := idx[x], idx[y]
+				if find(i) != find(j) && IsDuplicate(cands[i], cands[j]) {
+					parent[find(i)] = find(j)
+				}
+			}
+		}
+	}
+
+	groups := make(map[int][]int)
+	for i := range cands {
+		root := find(i)
+		groups[root] = append(groups[root], i)
+	}
+
+	primaries := make(map[string]string)
+	for _, members := range groups {
+		if len(members) < 2 {
+			continue
+		}
+		// Richest first, then earliest published, then id for a stable pick
+		sort.Slice(members, func(x, y int) bool {
+			a, b := cands[members[x]], cands[members[y]]
+			if ra, rb := richness(a), richness(b); ra != rb {
+				return ra > rb
+			}
+			if !a.PublishedAt.Equal(b.PublishedAt) {
+				return a.PublishedAt.Before(b.PublishedAt)
+			}
+			return a.ID < b.ID
+		})
+		primary := cands[members[0]].ID
+		for _, m := range members {
+			primaries[cands[m].ID] = primary
+		}
+	}
+	return primaries
+}
@@ -0,0 +1,96 @@
+package dedupe
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+const description = `We are hiring a senior backend engineer to build payment APIs in Go.
+You will own services end to end, work with PostgreSQL and Kafka, and mentor others.`
+
+func TestIsDuplicate(t *testing.T) {
+	hash := SimHash(description)
+	other := SimHash("Design delightful mobile onboarding flows in Figma with our product team and run user research sessions every week.")
+
+	base := Candidate{ID: "a", Source: "remotive", CompanyID: "acme", Title: "Senior Backend Engineer", SimHash: hash}
+	tests := []struct {
+		name string
+		b    Candidate
+		want bool
+	}{
+		{"same description on another source", Candidate{ID: "b", Source: "wwr", CompanyID: "acme", Title: "Sr. Backend Engineer (Remote)", SimHash: hash}, true},
+		{"same source", Candidate{ID: "b", Source: "remotive", CompanyID: "acme", Title: "Senior Backend Engineer", SimHash: hash}, false},
+		{"other company", Candidate{ID: "b", Source: "wwr", CompanyID: "globex", Title: "Senior Backend Engineer", SimHash: hash}, false},
+		{"different role", Candidate{ID: "b", Source: "wwr", CompanyID: "acme", Title: "Product Designer", SimHash: hash}, false},
+		{"same title, unrelated description", Candidate{ID: "b", Source: "wwr", CompanyID: "acme", Title: "Senior Backend Engineer", SimHash: other}, false},
+		{"same title, close embeddings", Candidate{ID: "b", Source: "wwr", CompanyID: "acme", Title: "Senior Backend Engineer", Vector: []float32{1, 0.1}}, true},
+	}
+	a := base
+	a.Vector = []float32{1, 0.12}
+	for _, tt := range tests {
+		if got := IsDuplicate(a, tt.b); got != tt.want {
+			t.Errorf("%s: IsDuplicate = %v, want %v", tt.name, got, tt.want)
+		}
+		if got := IsDuplicate(tt.b, a); got != tt.want {
+			t.Errorf("%s: IsDuplicate reversed = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	noCompany := base
+	noCompany.CompanyID = ""
+	twin := noCompany
+	twin.Source = "wwr"
+	if IsDuplicate(noCompany, twin) {
+		t.Error("postings without a company were treated as duplicates")
+	}
+}
+
+func TestCluster(t *testing.T) {
+	hash := SimHash(description)
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	cands := []Candidate{
+		{ID: "remotive-1", Source: "remotive", CompanyID: "acme", Title: "Senior Backend Engineer", SimHash: hash, DescriptionLength: 800, PublishedAt: day},
+		{ID: "wwr-1", Source: "wwr", CompanyID: "acme", Title: "Senior Backend Engineer", SimHash: hash, DescriptionLength: 800, HasSalary: true, PublishedAt: day.Add(time.Hour)},
+		{ID: "jooble-1", Source: "jooble", CompanyID: "acme", Title: "Sr Backend Engineer", SimHash: hash, DescriptionLength: 200, PublishedAt: day},
+		{ID: "wwr-2", Source: "wwr", CompanyID: "acme", Title: "Product Designer", DescriptionLength: 900},
+		{ID: "remotive-2", Source: "remotive", CompanyID: "globex", Title: "Senior Backend Engineer", SimHash: hash},
+	}
+
+	got := Cluster(cands)
+	// The copy with a salary is richest and becomes the primary
+	want := map[string]string{"remotive-1": "wwr-1", "wwr-1": "wwr-1", "jooble-1": "wwr-1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Cluster = %v, want %v", got, want)
+	}
+}
+
+func TestClusterTieBreak(t *testing.T) {
+	hash := SimHash(description)
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	cands := []Candidate{
+		{ID: "b", Source: "wwr", CompanyID: "acme", Title: "Backend Engineer", SimHash: hash, PublishedAt: day.Add(time.Hour)},
+		{ID: "a", Source: "remotive", CompanyID: "acme", Title: "Backend Engineer", SimHash: hash, PublishedAt: day},
+	}
+	// Equally rich copies: the earliest published wins
+	if got := Cluster(cands); got["b"] != "a" || got["a"] != "a" {
+		t.Errorf("Cluster = %v, want both pointing at a", got)
+	}
+}
+
+func TestTitleSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		min  float64
+		max  float64
+	}{
+		{"Senior Backend Engineer", "Sr. Back-end Engineer (m/f/d)", 1, 1},
+		{"Senior Backend Engineer", "Backend Engineer", 0.5, 0.99},
+		{"Senior Backend Engineer", "Product Designer", 0, 0},
+	}
+	for _, tt := range tests {
+		if got := TitleSimilarity(tt.a, tt.b); got < tt.min || got > tt.max {
+			t.Errorf("TitleSimilarity(%q, %q) = %v, want between %v and %v", tt.a, tt.b, got, tt.min, tt.max)
+		}
+	}
+}
@@ -2,14 +2,16 @@ package fetch
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
 
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/logger"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/salary"
 	"github.com/sanchitb23/remote-job-radar/aggregator/internal/storage"
+	"go.uber.org/zap"
 )
 
 type adzResp struct {
@@ -33,7 +35,128 @@ type adzResp struct {
 	} `json:"results"`
 }
 
-func Adzuna(ctx context.Context, page int, appID, appKey, baseURL string, jobCount int) ([]storage.JobRow, error) {
+// AdzunaQuery holds the search filters sent to Adzuna for one country
+type AdzunaQuery struct {
+	Country    string // ISO country code of the Adzuna index, e.g. "us", "gb"
+	What       string // keywords that must all match
+	WhatOr     string // space-separated keywords of which any may match
+	Where      string // location filter
+	MaxDaysOld int    // 0 means no age limit
+}
+
+// Currency of each Adzuna country index, which reports salaries in local currency
+var adzunaCurrencies = map[string]string{
+	"at": "EUR", "au": "AUD", "be": "EUR", "br": "BRL", "ca": "CAD", "ch": "CHF",
+	"de": "EUR", "es": "EUR", "fr": "EUR", "gb": "GBP", "in": "INR", "it": "EUR",
+	"mx": "MXN", "nl": "EUR", "nz": "NZD", "pl": "PLN", "sg": "SGD", "us": "USD",
+	"za": "ZAR",
+}
+
+// AdzunaSource pulls jobs from the paginated Adzuna search API across several countries
+type AdzunaSource struct {
+	baseURL   string
+	appID     string
+	appKey    string
+	countries []string
+	query     AdzunaQuery
+}
+
+// NewAdzunaSource creates an Adzuna source searching every country in countries with
+// the filters in query (its Country is ignored). Empty baseURL uses the public API.
+func NewAdzunaSource(baseURL, appID, appKey string, countries []string, query AdzunaQuery) *AdzunaSource {
+	if len(countries) == 0 {
+		countries = []string{"us"}
+	}
+	return &AdzunaSource{baseURL: baseURL, appID: appID, appKey: appKey, countries: countries, query: query}
+}
+
+func (s *AdzunaSource) Name() string { return "adzuna" }
+
+// Enabled returns true if Adzuna API credentials are configured
+func (s *AdzunaSource) Enabled() bool { return s.appID != "" && s.appKey != "" }
+
+// Fetch searches each configured country in turn and merges the results. The same
+// posting is often listed in several country indexes, so jobs are deduplicated,
+// see adzunaKeys.
+func (s *AdzunaSource) Fetch(ctx context.Context, opts Options) ([]storage.JobRow, error) {
+	var all []storage.JobRow
+	seen := make(map[string]struct{})
+	var lastErr error
+
+	for _, country := range s.countries {
+		if opts.JobCount > 0 && len(all) >= opts.JobCount {
+			break
+		}
+
+		countryOpts := opts
+		if opts.JobCount > 0 {
+			countryOpts.JobCount = opts.JobCount - len(all)
+		}
+		query := s.query
+		query.Country = strings.ToLower(country)
+
+		jobs, err := Paginate(ctx, s.Name()+"-"+query.Country, countryOpts, 50, func(ctx context.Context, page int) ([]storage.JobRow, error) {
+			return Adzuna(ctx, page, s.appID, s.appKey, s.baseURL, query, countryOpts.JobCount)
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return all, ctx.Err()
+			}
+			if Kind(err) == KindAuth {
+				// Every country uses the same credentials, don't try the rest
+				return nil, err
+			}
+			logger.Error("Adzuna country fetch error",
+				zap.String("country", query.Country),
+				zap.String("kind", string(Kind(err))),
+				zap.Error(err))
+			lastErr = err
+			continue
+		}
+
+		added := 0
+	merge:
+		for _, job := range jobs {
+			keys := adzunaKeys(job)
+			for _, key := range keys {
+				if _, dup := seen[key]; dup {
+					continue merge
+				}
+			}
+			for _, key := range keys {
+				seen[key] = struct{}{}
+			}
+			all = append(all, job)
+			added++
+		}
+		logger.Info("Merged Adzuna country results",
+			zap.String("country", query.Country),
+			zap.Int("fetched", len(jobs)),
+			zap.Int("added", added))
+	}
+
+	// Only fail the source when every country failed
+	if len(all) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return limitJobs(all, opts.JobCount), nil
+}
+
+// adzunaKeys returns what a posting is recognized by across country indexes:
+// its ID, and its title and company. Without a company the title alone would
+// merge unrelated postings, so the redirect URL is used instead.
+func adzunaKeys(job storage.JobRow) []string {
+	company := strings.ToLower(strings.TrimSpace(job.Company))
+	if company == "" {
+		if job.URL == "" {
+			return []string{job.ID}
+		}
+		return []string{job.ID, "url|" + job.URL}
+	}
+	return []string{job.ID, "title|" + strings.ToLower(strings.TrimSpace(job.Title)) + "|" + company}
+}
+
+func Adzuna(ctx context.Context, page int, appID, appKey, baseURL string, query AdzunaQuery, jobCount int) ([]storage.JobRow, error) {
 	if appID == "" || appKey == "" {
 		return nil, fmt.Errorf("adzuna API credentials are required")
 	}
@@ -55,39 +178,52 @@ func Adzuna(ctx context.Context, page int, appID, appKey, baseURL string, jobCou
 		"results_per_page": {fmt.Sprintf("%d", resultsPerPage)},
 		"sort_by":          {"date"},
 	}
+	if query.What != "" {
+		q.Set("what", query.What)
+	}
+	if query.WhatOr != "" {
+		q.Set("what_or", query.WhatOr)
+	}
+	if query.Where != "" {
+		q.Set("where", query.Where)
+	}
+	if query.MaxDaysOld > 0 {
+		q.Set("max_days_old", fmt.Sprintf("%d", query.MaxDaysOld))
+	}
+
+	country := query.Country
+	if country == "" {
+		country = "us"
+	}
 
 	if baseURL == "" {
 		baseURL = "https://api.adzuna.com"
 	}
 	// Remove trailing slash if present
 	baseURL = strings.TrimRight(baseURL, "/")
-	endpointPath := fmt.Sprintf("/v1/api/jobs/us/search/%d?%s", page, q.Encode())
+	endpointPath := fmt.Sprintf("/v1/api/jobs/%s/search/%d?%s", url.PathEscape(country), page, q.Encode())
 	endpt := baseURL + endpointPath
 
 	req, err := http.NewRequestWithContext(ctx, "GET", endpt, nil)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := http.DefaultClient.Do(req)
+	// Non-2xx responses come back as typed errors, 401 meaning bad credentials
+	resp, err := HTTPClient().Do(req)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("adzuna: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Check for non-200 status codes and log the response body for debugging
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("adzuna: status %d, body: %s", resp.StatusCode, string(body))
-	}
 	// Check for non-JSON content-type (e.g., HTML error page)
 	if ct := resp.Header.Get("Content-Type"); ct != "" && !strings.Contains(ct, "json") {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("adzuna: unexpected content-type %s, body: %s", ct, string(body))
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return nil, fmt.Errorf("adzuna: %w", &DecodeError{Err: fmt.Errorf("unexpected content-type %s, body: %s", ct, string(body))})
 	}
 
 	var data adzResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, err
+	if err = decodeJSON(resp, &data); err != nil {
+		return nil, fmt.Errorf("adzuna: %w", err)
 	}
 
 	out := make([]storage.JobRow, 0, len(data.Results))
@@ -101,19 +237,19 @@ func Adzuna(ctx context.Context, page int, appID, appKey, baseURL string, jobCou
 		workType = strings.TrimSuffix(workType, " Job")
 		workType = strings.TrimSuffix(workType, " job")
 
-		out = append(out, storage.JobRow{
-			ID:          id,
-			Source:      "adzuna",
-			Title:       j.Title,
-			Company:     j.Company.DisplayName,
-			Description: j.Description,
-			Location:    j.Location.DisplayName,
-			WorkType:    workType,
-			SalaryMin:   int(j.SalaryMin),
-			SalaryMax:   int(j.SalaryMax),
-			URL:         j.RedirectURL,
-			PublishedAt: j.Created,
-		})
+		// Adzuna reports annual salaries in the currency of the country index
+		out = append(out, withSalary(storage.JobRow{
+			ID:           id,
+			Source:       "adzuna",
+			Title:        j.Title,
+			Company:      j.Company.DisplayName,
+			Description:  j.Description,
+			Location:     j.Location.DisplayName,
+			WorkType:     workType,
+			URL:          j.RedirectURL,
+			PublishedAt:  parsePublished("adzuna", j.Created),
+			PublishedRaw: j.Created,
+		}, salary.Salary{Min: j.SalaryMin, Max: j.SalaryMax, Currency: adzunaCurrencies[country], Period: salary.Yearly}))
 	}
 	return out, nil
 }
@@ -0,0 +1,37 @@
+package fetch
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/storage"
+)
+
+func TestAdzunaKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		job  storage.JobRow
+		want []string
+	}{
+		{
+			name: "title and company",
+			job:  storage.JobRow{ID: "adzuna-1", Title: " Go Engineer", Company: "Acme ", URL: "https://adzuna.com/land/ad/1"},
+			want: []string{"adzuna-1", "title|go engineer|acme"},
+		},
+		{
+			name: "no company falls back to the redirect URL",
+			job:  storage.JobRow{ID: "adzuna-2", Title: "Go Engineer", URL: "https://adzuna.com/land/ad/2"},
+			want: []string{"adzuna-2", "url|https://adzuna.com/land/ad/2"},
+		},
+		{
+			name: "nothing but the id",
+			job:  storage.JobRow{ID: "adzuna-3", Title: "Go Engineer"},
+			want: []string{"adzuna-3"},
+		},
+	}
+	for _, tt := range tests {
+		if got := adzunaKeys(tt.job); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: adzunaKeys = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/salary"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/storage"
+)
+
+type ashbyResp struct {
+	Jobs []struct {
+		ID              string `json:"id"`
+		Title           string `json:"title"`
+		Department      string `json:"department"`
+		Location        string `json:"location"`
+		IsListed        bool   `json:"isListed"`
+		IsRemote        bool   `json:"isRemote"`
+		WorkplaceType   string `json:"workplaceType"`  // "Remote", "Hybrid", "OnSite"
+		EmploymentType  string `json:"employmentType"` // "FullTime", "PartTime", "Intern", "Contract", "Temporary"
+		DescriptionHTML string `json:"descriptionHtml"`
+		PublishedAt     string `json:"publishedAt"` // "2021-04-30T16:21:55.393+00:00"
+		JobURL          string `json:"jobUrl"`
+		ApplyURL        string `json:"applyUrl"`
+		Compensation    *struct {
+			SummaryComponents []struct {
+				CompensationType string   `json:"compensationType"` // "Salary", "EquityPercentage", ...
+				Interval         string   `json:"interval"`         // "1 YEAR", "1 HOUR", ...
+				CurrencyCode     string   `json:"currencyCode"`
+				MinValue         *float64 `json:"minValue"`
+				MaxValue         *float64 `json:"maxValue"`
+			} `json:"summaryComponents"`
+		} `json:"compensation"`
+	} `json:"jobs"`
+}
+
+// AshbySource pulls postings for configured companies from the Ashby job board API
+type AshbySource struct {
+	baseURL string
+	boards  []string
+}
+
+// NewAshbySource creates an Ashby source for the given job board names,
+// empty baseURL uses the public posting API
+func NewAshbySource(baseURL string, boards []string) *AshbySource {
+	return &AshbySource{baseURL: baseURL, boards: boards}
+}
+
+func (s *AshbySource) Name() string { return "ashby" }
+
+// Enabled returns true if at least one job board is configured
+func (s *AshbySource) Enabled() bool { return len(s.boards) > 0 }
+
+func (s *AshbySource) Fetch(ctx context.Context, opts Options) ([]storage.JobRow, error) {
+	return FetchCompanies(ctx, s.Name(), s.boards, opts, func(ctx context.Context, board string) ([]storage.JobRow, error) {
+		return Ashby(ctx, s.baseURL, board)
+	})
+}
+
+// Ashby fetches every listed posting on a single company job board. The
+// board API doesn't return the company name, so board is the board name
+// optionally followed by the name to show ("acme=Acme Inc").
+func Ashby(ctx context.Context, baseURL, board string) ([]storage.JobRow, error) {
+	if baseURL == "" {
+		baseURL = "https://api.ashbyhq.com"
+	}
+	board, name := companyBoard(board)
+	endpt := fmt.Sprintf("%s/posting-api/job-board/%s?includeCompensation=true",
+		strings.TrimRight(baseURL, "/"), url.PathEscape(board))
+
+	var data ashbyResp
+	if err := getJSON(ctx, endpt, &data); err != nil {
+		return nil, fmt.Errorf("ashby %s: %w", board, err)
+	}
+
+	out := make([]storage.JobRow, 0, len(data.Jobs))
+	for _, j := range data.Jobs {
+		if !j.IsListed {
+			continue
+		}
+
+		// isRemote is authoritative even when workplaceType is missing
+		workplaceType := j.WorkplaceType
+		if j.IsRemote {
+			workplaceType = "remote"
+		}
+
+		// The first salary component with a known interval is the base pay
+		var pay salary.Salary
+		if j.Compensation != nil {
+			for _, c := range j.Compensation.SummaryComponents {
+				period := salary.ParsePeriod(c.Interval)
+				if c.CompensationType != "Salary" || period == "" {
+					continue
+				}
+				pay = salary.Salary{Currency: strings.ToUpper(c.CurrencyCode), Period: period}
+				if c.MinValue != nil {
+					pay.Min = *c.MinValue
+				}
+				if c.MaxValue != nil {
+					pay.Max = *c.MaxValue
+				}
+				break
+			}
+		}
+
+		applyURL := j.ApplyURL
+		if applyURL == "" {
+			applyURL = j.JobURL
+		}
+
+		out = append(out, withSalary(storage.JobRow{
+			ID:            fmt.Sprintf("ashby-%s-%s", strings.ToLower(board), j.ID),
+			Source:        "ashby",
+			Title:         j.Title,
+			Company:       name,
+			Description:   j.DescriptionHTML,
+			Location:      j.Location,
+			WorkplaceType: workplaceType,
+			WorkType:      ashbyEmploymentType(j.EmploymentType),
+			URL:           applyURL,
+			PublishedAt:   parsePublished("ashby", j.PublishedAt),
+			PublishedRaw:  j.PublishedAt,
+		}, pay))
+	}
+	return out, nil
+}
+
+// ashbyEmploymentType turns Ashby's enum ("FullTime") into the display form
+// the other sources use ("Full-time")
+func ashbyEmploymentType(employmentType string) string {
+	switch employmentType {
+	case "FullTime":
+		return "Full-time"
+	case "PartTime":
+		return "Part-time"
+	case "Intern":
+		return "Internship"
+	default:
+		return employmentType
+	}
+}
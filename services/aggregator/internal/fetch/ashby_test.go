@@ -0,0 +1,63 @@
+package fetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAshby(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/posting-api/job-board/acme" || r.URL.Query().Get("includeCompensation") != "true" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"jobs": [
+			{"id": "j1", "title": "Backend Engineer", "location": "Berlin", "isListed": true,
+			 "isRemote": true, "workplaceType": "Hybrid", "employmentType": "FullTime",
+			 "descriptionHtml": "<p>APIs</p>", "publishedAt": "2026-10-05T10:00:00.000+00:00",
+			 "jobUrl": "https://jobs.ashbyhq.com/acme/j1",
+			 "compensation": {"summaryComponents": [
+				{"compensationType": "EquityPercentage", "interval": "NONE", "minValue": 0.1, "maxValue": 0.2},
+				{"compensationType": "Salary", "interval": "1 YEAR", "currencyCode": "eur", "minValue": 70000, "maxValue": 90000}
+			 ]}},
+			{"id": "j2", "title": "Office Manager", "location": "Paris", "isListed": true,
+			 "workplaceType": "OnSite", "employmentType": "PartTime",
+			 "jobUrl": "https://jobs.ashbyhq.com/acme/j2", "applyUrl": "https://jobs.ashbyhq.com/acme/j2/application"},
+			{"id": "j3", "title": "Unlisted", "isListed": false}
+		]}`))
+	}))
+	defer srv.Close()
+
+	jobs, err := Ashby(context.Background(), srv.URL, "acme=Acme Inc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want the 2 listed ones", len(jobs))
+	}
+
+	first := jobs[0]
+	if first.ID != "ashby-acme-j1" || first.Company != "Acme Inc" {
+		t.Errorf("ID %q, company %q", first.ID, first.Company)
+	}
+	// isRemote wins over workplaceType, and neither ends up in the location
+	if first.Location != "Berlin" || first.WorkplaceType != "remote" {
+		t.Errorf("location %q, workplace %q", first.Location, first.WorkplaceType)
+	}
+	if first.SalaryMin != 70000 || first.SalaryMax != 90000 || first.SalaryCurrency != "EUR" {
+		t.Errorf("salary = %d-%d %s, want the Salary component", first.SalaryMin, first.SalaryMax, first.SalaryCurrency)
+	}
+	if first.WorkType != "Full-time" || first.URL != "https://jobs.ashbyhq.com/acme/j1" {
+		t.Errorf("work type %q, URL %q", first.WorkType, first.URL)
+	}
+
+	second := jobs[1]
+	if second.Location != "Paris" || second.WorkplaceType != "OnSite" || second.WorkType != "Part-time" {
+		t.Errorf("location %q, workplace %q, work type %q", second.Location, second.WorkplaceType, second.WorkType)
+	}
+	if second.URL != "https://jobs.ashbyhq.com/acme/j2/application" {
+		t.Errorf("URL = %q, want the application form", second.URL)
+	}
+}
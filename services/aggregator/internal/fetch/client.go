@@ -0,0 +1,300 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/logger"
+	"go.uber.org/zap"
+)
+
+// ClientConfig configures the HTTP client shared by all job sources
+type ClientConfig struct {
+	UserAgent      string
+	RequestTimeout time.Duration // per attempt, 0 disables
+	MaxRetries     int           // retries after the first attempt
+	BaseDelay      time.Duration // first backoff delay, doubled per retry
+	MaxDelay       time.Duration // cap for backoff, and for Retry-After waits without a deadline
+	// RateLimit is the default per-host request rate (requests/second), 0 disables
+	RateLimit float64
+	RateBurst int
+	// HostRateLimits overrides RateLimit for specific hosts, e.g. "remoteok.com": 0.5
+	HostRateLimits map[string]float64
+	// Transport is the underlying round tripper, nil uses http.DefaultTransport
+	Transport http.RoundTripper
+}
+
+// DefaultClientConfig returns conservative settings suitable for public job APIs
+func DefaultClientConfig() ClientConfig {
+	return ClientConfig{
+		UserAgent:      "remote-job-radar-aggregator/1.0",
+		RequestTimeout: 30 * time.Second,
+		MaxRetries:     3,
+		BaseDelay:      time.Second,
+		MaxDelay:       30 * time.Second,
+		RateLimit:      2,
+		RateBurst:      5,
+	}
+}
+
+// Client is an HTTP client with per-host token-bucket rate limiting, per-request
+// timeouts and jittered exponential backoff that honours Retry-After
+type Client struct {
+	cfg        ClientConfig
+	httpClient *http.Client
+
+	mu       sync.Mutex
+	limiters map[string]*tokenBucket
+}
+
+// NewClient creates a client from cfg
+func NewClient(cfg ClientConfig) *Client {
+	if cfg.RateBurst <= 0 {
+		cfg.RateBurst = 1
+	}
+	transport := cfg.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Client{
+		cfg:        cfg,
+		httpClient: &http.Client{Transport: transport},
+		limiters:   make(map[string]*tokenBucket),
+	}
+}
+
+var (
+	httpClientMu sync.RWMutex
+	httpClient   = NewClient(DefaultClientConfig())
+)
+
+// SetHTTPClient replaces the client used by every source
+func SetHTTPClient(c *Client) {
+	httpClientMu.Lock()
+	defer httpClientMu.Unlock()
+	httpClient = c
+}
+
+// HTTPClient returns the client used by every source
+func HTTPClient() *Client {
+	httpClientMu.RLock()
+	defer httpClientMu.RUnlock()
+	return httpClient
+}
+
+// Do sends req, waiting for the host's rate limit and retrying failures that
+// IsRetryable accepts. Non-2xx responses are returned as typed errors (see
+// CheckResponse) with the body closed. On success the caller must close the body.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if c.cfg.UserAgent != "" && req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", c.cfg.UserAgent)
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := c.limiter(req.URL.Hostname()).Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.attempt(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			err = &NetworkError{Err: err}
+		} else if err = CheckResponse(resp); err != nil {
+			// Drain so the connection can be reused
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		} else {
+			return resp, nil
+		}
+
+		if !IsRetryable(err) || attempt >= c.cfg.MaxRetries {
+			return nil, err
+		}
+
+		delay, ok := c.backoff(ctx, attempt, err)
+		if !ok {
+			// Retrying before the source's reset would only be throttled again
+			return nil, err
+		}
+		logger.Warn("Retrying source request",
+			zap.String("host", req.URL.Hostname()),
+			zap.Int("attempt", attempt+1),
+			zap.Int("maxRetries", c.cfg.MaxRetries),
+			zap.Duration("delay", delay),
+			zap.String("kind", string(Kind(err))),
+			zap.Error(err))
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		if req.Body != nil && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+	}
+}
+
+// Get issues a GET request for url through the client
+func (c *Client) Get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(req)
+}
+
+// attempt sends a single request bounded by the per-request timeout. The timeout
+// stays active until the response body is closed.
+func (c *Client) attempt(req *http.Request) (*http.Response, error) {
+	if c.cfg.RequestTimeout <= 0 {
+		return c.httpClient.Do(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), c.cfg.RequestTimeout)
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// backoff returns the wait before the next attempt. When the source said when
+// its rate limit resets that's the wait, as long as it ends before ctx's
+// deadline, or within MaxDelay when ctx has none; otherwise ok is false and
+// the request should give up. Other errors get exponential backoff with
+// jitter, capped at MaxDelay.
+func (c *Client) backoff(ctx context.Context, attempt int, err error) (delay time.Duration, ok bool) {
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) && !rateLimitErr.ResetAt.IsZero() {
+		wait := max(time.Until(rateLimitErr.ResetAt), 0)
+		if deadline, has := ctx.Deadline(); has {
+			return wait, time.Now().Add(wait).Before(deadline)
+		}
+		return wait, c.cfg.MaxDelay <= 0 || wait <= c.cfg.MaxDelay
+	}
+
+	delay = minDuration(c.cfg.BaseDelay*(1<<attempt), c.cfg.MaxDelay)
+	if delay <= 0 {
+		return 0, true
+	}
+	// Equal jitter: half fixed, half random
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1)), true
+}
+
+func (c *Client) limiter(host string) *tokenBucket {
+	host = strings.ToLower(host)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if l, ok := c.limiters[host]; ok {
+		return l
+	}
+	rate := c.cfg.RateLimit
+	if r, ok := c.cfg.HostRateLimits[host]; ok {
+		rate = r
+	}
+	l := newTokenBucket(rate, c.cfg.RateBurst)
+	c.limiters[host] = l
+	return l
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		wait := time.Until(at)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if b > 0 && b < a {
+		return b
+	}
+	return a
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// tokenBucket is a minimal token-bucket rate limiter
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second, <= 0 means unlimited
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// Wait blocks until a token is available or ctx is done
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	if b.rate <= 0 {
+		return ctx.Err()
+	}
+
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		switch r.URL.Path {
+		case "/long":
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/short":
+			if n == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer srv.Close()
+
+	// MaxDelay is below both waits: it only caps backoff, not the source's reset
+	client := NewClient(ClientConfig{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond})
+
+	t.Run("reset after the deadline gives up", func(t *testing.T) {
+		calls.Store(0)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		start := time.Now()
+		_, err := client.Get(ctx, srv.URL+"/long")
+		var rateLimitErr *RateLimitError
+		if !errors.As(err, &rateLimitErr) || rateLimitErr.ResetAt.IsZero() {
+			t.Fatalf("error = %v, want a RateLimitError with its reset time", err)
+		}
+		if calls.Load() != 1 || time.Since(start) > time.Second {
+			t.Errorf("%d requests in %v, want one and no early retry", calls.Load(), time.Since(start))
+		}
+	})
+
+	t.Run("reset before the deadline is waited out", func(t *testing.T) {
+		calls.Store(0)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		start := time.Now()
+		resp, err := client.Get(ctx, srv.URL+"/short")
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if waited := time.Since(start); waited < time.Second {
+			t.Errorf("retried after %v, want the full Retry-After", waited)
+		}
+	})
+
+	t.Run("no deadline falls back to MaxDelay", func(t *testing.T) {
+		calls.Store(0)
+		if _, err := client.Get(context.Background(), srv.URL+"/short"); Kind(err) != KindRateLimit {
+			t.Errorf("error = %v, want the rate limit", err)
+		}
+		if calls.Load() != 1 {
+			t.Errorf("%d requests, want one", calls.Load())
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/logger"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/storage"
+	"go.uber.org/zap"
+)
+
+// ErrNotModified is returned by a source whose content hasn't changed since the
+// last stored run
+var ErrNotModified = errors.New("not modified")
+
+// ValidatorStore persists the ETag/Last-Modified validators of source URLs
+type ValidatorStore interface {
+	GetHTTPValidators(ctx context.Context, url string) (storage.HTTPValidators, error)
+	SaveHTTPValidators(ctx context.Context, url string, v storage.HTTPValidators) error
+}
+
+// ConditionalCache sends conditional GETs during a fetch run. Validators from
+// new responses are only staged once their body decoded (MarkDecoded) and are
+// held until Commit, so a run whose payload is malformed or whose jobs fail to
+// store refetches everything next time instead of getting a 304.
+type ConditionalCache struct {
+	store ValidatorStore
+
+	mu      sync.Mutex
+	fetched map[string]storage.HTTPValidators // responses not yet decoded
+	pending map[string]storage.HTTPValidators
+}
+
+// NewConditionalCache creates a cache for a single fetch run
+func NewConditionalCache(store ValidatorStore) *ConditionalCache {
+	return &ConditionalCache{
+		store:   store,
+		fetched: make(map[string]storage.HTTPValidators),
+		pending: make(map[string]storage.HTTPValidators),
+	}
+}
+
+// Get issues a GET for url with If-None-Match/If-Modified-Since from the saved
+// validators and returns ErrNotModified on a 304. Call MarkDecoded once the
+// body is decoded. A nil cache sends a plain GET.
+func (c *ConditionalCache) Get(ctx context.Context, url string) (*http.Response, error) {
+	if c == nil {
+		return HTTPClient().Get(ctx, url)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := c.store.GetHTTPValidators(ctx, url)
+	if err != nil {
+		// Not fatal, the request just isn't conditional
+		logger.Warn("Failed to load HTTP validators", zap.String("url", url), zap.Error(err))
+	}
+	if saved.ETag != "" {
+		req.Header.Set("If-None-Match", saved.ETag)
+	}
+	if saved.LastModified != "" {
+		req.Header.Set("If-Modified-Since", saved.LastModified)
+	}
+
+	resp, err := HTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		return nil, ErrNotModified
+	}
+
+	fresh := storage.HTTPValidators{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+	c.mu.Lock()
+	if fresh != saved {
+		c.fetched[url] = fresh
+	} else {
+		delete(c.fetched, url)
+	}
+	c.mu.Unlock()
+	return resp, nil
+}
+
+// MarkDecoded stages the validators of url's response for Commit. A response
+// that's never marked, e.g. because its body failed to decode, keeps the old
+// validators so the next run gets the full body again.
+func (c *ConditionalCache) MarkDecoded(url string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if v, ok := c.fetched[url]; ok {
+		c.pending[url] = v
+		delete(c.fetched, url)
+	}
+}
+
+// Commit saves the validators of every response fetched in this run. Call it
+// only once the fetched jobs are stored.
+func (c *ConditionalCache) Commit(ctx context.Context) error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for url, v := range c.pending {
+		if err := c.store.SaveHTTPValidators(ctx, url, v); err != nil {
+			return err
+		}
+		delete(c.pending, url)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/storage"
+)
+
+// memValidators is an in-memory ValidatorStore
+type memValidators map[string]storage.HTTPValidators
+
+func (m memValidators) GetHTTPValidators(_ context.Context, url string) (storage.HTTPValidators, error) {
+	return m[url], nil
+}
+
+func (m memValidators) SaveHTTPValidators(_ context.Context, url string, v storage.HTTPValidators) error {
+	m[url] = v
+	return nil
+}
+
+func TestConditionalCacheSkipsUndecodedResponses(t *testing.T) {
+	body := `{"jobs": [`
+	var ifNoneMatch string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch = r.Header.Get("If-None-Match")
+		if ifNoneMatch == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	store := memValidators{}
+	run := func() error {
+		cache := NewConditionalCache(store)
+		if _, err := Remotive(context.Background(), cache, srv.URL, 0); err != nil {
+			return err
+		}
+		return cache.Commit(context.Background())
+	}
+
+	// A truncated body must not leave its ETag behind
+	var decodeErr *DecodeError
+	if err := run(); !errors.As(err, &decodeErr) {
+		t.Fatalf("first run error = %v, want a DecodeError", err)
+	}
+	if err := run(); !errors.As(err, &decodeErr) {
+		t.Fatalf("second run error = %v, want a DecodeError", err)
+	}
+	if ifNoneMatch != "" {
+		t.Errorf("run after a decode error sent If-None-Match %q", ifNoneMatch)
+	}
+
+	body = `{"jobs": []}`
+	if err := run(); err != nil {
+		t.Fatal(err)
+	}
+	if err := run(); !errors.Is(err, ErrNotModified) {
+		t.Errorf("run after a decoded response: error = %v, want ErrNotModified", err)
+	}
+}
@@ -0,0 +1,108 @@
+package fetch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/logger"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/storage"
+	"go.uber.org/zap"
+)
+
+// CursorStore persists the newest published_at seen per source query
+type CursorStore interface {
+	GetSourceCursor(ctx context.Context, key string) (time.Time, error)
+	SaveSourceCursor(ctx context.Context, key string, newest time.Time) error
+}
+
+// Cursors lets paginated sources stop once they reach postings an earlier run
+// already stored. Like ConditionalCache, advanced cursors are held until Commit
+// so a run whose jobs fail to store doesn't skip them next time.
+type Cursors struct {
+	store CursorStore
+
+	mu      sync.Mutex
+	pending map[string]time.Time
+}
+
+// NewCursors creates the cursors for a single fetch run
+func NewCursors(store CursorStore) *Cursors {
+	return &Cursors{store: store, pending: make(map[string]time.Time)}
+}
+
+// Since returns the newest published_at stored for key, zero when there is
+// none or c is nil
+func (c *Cursors) Since(ctx context.Context, key string) time.Time {
+	if c == nil {
+		return time.Time{}
+	}
+	since, err := c.store.GetSourceCursor(ctx, key)
+	if err != nil {
+		// Not fatal, the source just pages as far as it's allowed to
+		logger.Warn("Failed to load source cursor", zap.String("key", key), zap.Error(err))
+		return time.Time{}
+	}
+	return since
+}
+
+// MaxPublishedSkew is how far ahead of now a publish date may be before it's
+// taken for a timezone mix-up rather than a scheduled posting
+const MaxPublishedSkew = 24 * time.Hour
+
+// Advance records the newest published_at among jobs for key. Dates further
+// in the future than MaxPublishedSkew are ignored: saved cursors only move
+// forward, so one would stop pagination at the first page for good.
+func (c *Cursors) Advance(key string, jobs []storage.JobRow) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	limit := time.Now().Add(MaxPublishedSkew)
+	for _, job := range jobs {
+		if job.PublishedAt.After(limit) {
+			continue
+		}
+		if job.PublishedAt.After(c.pending[key]) {
+			c.pending[key] = job.PublishedAt
+		}
+	}
+}
+
+// Commit saves every cursor advanced in this run. Call it only once the
+// fetched jobs are stored.
+func (c *Cursors) Commit(ctx context.Context) error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, newest := range c.pending {
+		if err := c.store.SaveSourceCursor(ctx, key, newest); err != nil {
+			return err
+		}
+		delete(c.pending, key)
+	}
+	return nil
+}
+
+// hasNewer reports whether any job was published after since. Jobs without a
+// readable date count as new, so they never end pagination early.
+func hasNewer(jobs []storage.JobRow, since time.Time) bool {
+	for _, job := range jobs {
+		if isNewer(job, since) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNewer reports whether job was published after since, or has no readable date
+func isNewer(job storage.JobRow, since time.Time) bool {
+	return job.PublishedAt.IsZero() || job.PublishedAt.After(since)
+}
@@ -0,0 +1,171 @@
+package fetch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrorKind classifies a fetch failure for retries, logs and run reports
+type ErrorKind string
+
+const (
+	KindNone      ErrorKind = ""
+	KindStatus    ErrorKind = "http_status"
+	KindRateLimit ErrorKind = "rate_limited"
+	KindAuth      ErrorKind = "credentials_invalid"
+	KindDecode    ErrorKind = "decode"
+	KindNetwork   ErrorKind = "network"
+	KindCanceled  ErrorKind = "canceled"
+	KindUnknown   ErrorKind = "unknown"
+)
+
+// maxErrorBody caps how much of an error response body is kept for logs
+const maxErrorBody = 512
+
+// StatusError is an unexpected HTTP status from a source
+type StatusError struct {
+	StatusCode int
+	Body       string // truncated response body, for debugging
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("status %d, body: %s", e.StatusCode, e.Body)
+}
+
+// RateLimitError means the source throttled us; ResetAt is when it expects
+// requests again, zero if it didn't say
+type RateLimitError struct {
+	StatusCode int
+	ResetAt    time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	if e.ResetAt.IsZero() {
+		return fmt.Sprintf("rate limited (status %d)", e.StatusCode)
+	}
+	return fmt.Sprintf("rate limited (status %d), resets at %s", e.StatusCode, e.ResetAt.Format(time.RFC3339))
+}
+
+// AuthError means the source rejected our credentials. Retrying won't help.
+type AuthError struct {
+	StatusCode int
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("credentials invalid (status %d)", e.StatusCode)
+}
+
+// DecodeError means the response didn't match the schema we expect
+type DecodeError struct {
+	Err error
+}
+
+func (e *DecodeError) Error() string { return "decode response: " + e.Err.Error() }
+
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// NetworkError is a transport failure: DNS, connection refused, reset, timeout
+type NetworkError struct {
+	Err error
+}
+
+func (e *NetworkError) Error() string { return "network: " + e.Err.Error() }
+
+func (e *NetworkError) Unwrap() error { return e.Err }
+
+// Kind classifies err, looking through wrapping
+func Kind(err error) ErrorKind {
+	var (
+		statusErr    *StatusError
+		rateLimitErr *RateLimitError
+		authErr      *AuthError
+		decodeErr    *DecodeError
+		networkErr   *NetworkError
+	)
+	switch {
+	case err == nil:
+		return KindNone
+	case errors.As(err, &authErr):
+		return KindAuth
+	case errors.As(err, &rateLimitErr):
+		return KindRateLimit
+	case errors.As(err, &statusErr):
+		return KindStatus
+	case errors.As(err, &decodeErr):
+		return KindDecode
+	case errors.As(err, &networkErr):
+		return KindNetwork
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return KindCanceled
+	default:
+		return KindUnknown
+	}
+}
+
+// IsRetryable reports whether repeating the request may succeed: network
+// failures, rate limits and 5xx responses
+func IsRetryable(err error) bool {
+	switch Kind(err) {
+	case KindNetwork, KindRateLimit:
+		return true
+	case KindStatus:
+		var statusErr *StatusError
+		errors.As(err, &statusErr)
+		return statusErr.StatusCode >= 500
+	default:
+		return false
+	}
+}
+
+// CheckResponse turns a non-2xx response into a typed error. The body is only
+// read for error responses. 304 is only sent to conditional requests, which
+// handle it themselves.
+func CheckResponse(resp *http.Response) error {
+	code := resp.StatusCode
+	switch {
+	case code >= 200 && code < 300, code == http.StatusNotModified:
+		return nil
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return &AuthError{StatusCode: code}
+	case code == http.StatusTooManyRequests,
+		// A 503 with Retry-After is the source asking us to back off
+		code == http.StatusServiceUnavailable && resp.Header.Get("Retry-After") != "":
+		return &RateLimitError{StatusCode: code, ResetAt: rateLimitReset(resp.Header)}
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return &StatusError{StatusCode: code, Body: strings.TrimSpace(string(body))}
+	}
+}
+
+// decodeJSON decodes a JSON response body into v
+func decodeJSON(resp *http.Response, v interface{}) error {
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return &DecodeError{Err: err}
+	}
+	return nil
+}
+
+// rateLimitReset reads when a throttled source accepts requests again from
+// Retry-After or X-RateLimit-Reset (unix seconds or seconds from now)
+func rateLimitReset(h http.Header) time.Time {
+	if wait, ok := retryAfter(h.Get("Retry-After")); ok {
+		return time.Now().Add(wait)
+	}
+	if v, err := strconv.ParseInt(strings.TrimSpace(h.Get("X-RateLimit-Reset")), 10, 64); err == nil && v > 0 {
+		if v > 1e9 {
+			return time.Unix(v, 0)
+		}
+		return time.Now().Add(time.Duration(v) * time.Second)
+	}
+	return time.Time{}
+}
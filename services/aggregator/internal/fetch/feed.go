@@ -0,0 +1,235 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/salary"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/storage"
+	"gopkg.in/yaml.v3"
+)
+
+// Columns a feed element can be mapped onto via FeedConfig.Fields
+const (
+	FeedFieldCompany  = "company"
+	FeedFieldLocation = "location"
+	FeedFieldWorkType = "work_type"
+	FeedFieldSalary   = "salary"
+)
+
+// FeedConfig declares an RSS/Atom job feed handled by the generic feed fetcher
+type FeedConfig struct {
+	// Name is the registry name used in ?sources=
+	Name string `yaml:"name"`
+	// Source is stored in jobs.source, defaults to Name
+	Source string `yaml:"source"`
+	URL    string `yaml:"url"`
+	// IDPrefix is prepended to the item GUID to build jobs.id, defaults to Name
+	IDPrefix   string      `yaml:"id_prefix"`
+	TitleSplit *TitleSplit `yaml:"title_split"`
+	// Fields maps a job column (company, location, work_type, salary) to a feed
+	// element: a custom RSS element ("region"), a namespaced extension
+	// ("job_listing:location"), "author" or "category"
+	Fields map[string]string `yaml:"fields"`
+	// Workplace is the workplace type of every job on the feed ("remote" for
+	// remote-only boards), used when an item's location doesn't say
+	Workplace string `yaml:"workplace"`
+	// FullList marks a feed that lists every live posting of its id_prefix, so
+	// items that drop off it are closed
+	FullList bool `yaml:"full_list"`
+}
+
+// TitleSplit extracts the company from item titles like "Company: Title" or "Title at Company"
+type TitleSplit struct {
+	Separator    string `yaml:"separator"`
+	CompanyFirst bool   `yaml:"company_first"`
+}
+
+type feedFile struct {
+	Feeds []FeedConfig `yaml:"feeds"`
+}
+
+// LoadFeedConfigs reads feed definitions from a YAML file with a top-level "feeds" list
+func LoadFeedConfigs(path string) ([]FeedConfig, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var ff feedFile
+	if err := yaml.Unmarshal(buf, &ff); err != nil {
+		return nil, fmt.Errorf("failed to parse feeds file %s: %w", path, err)
+	}
+
+	for i, fc := range ff.Feeds {
+		if fc.Name == "" || fc.URL == "" {
+			return nil, fmt.Errorf("feed #%d in %s: name and url are required", i+1, path)
+		}
+		for column := range fc.Fields {
+			switch column {
+			case FeedFieldCompany, FeedFieldLocation, FeedFieldWorkType, FeedFieldSalary:
+			default:
+				return nil, fmt.Errorf("feed %s: unknown field column %q", fc.Name, column)
+			}
+		}
+	}
+	return ff.Feeds, nil
+}
+
+// FeedSource pulls jobs from any RSS/Atom feed described by a FeedConfig
+type FeedSource struct {
+	cfg FeedConfig
+}
+
+// NewFeedSource creates a generic feed source
+func NewFeedSource(cfg FeedConfig) *FeedSource {
+	if cfg.Source == "" {
+		cfg.Source = cfg.Name
+	}
+	if cfg.IDPrefix == "" {
+		cfg.IDPrefix = cfg.Name
+	}
+	return &FeedSource{cfg: cfg}
+}
+
+func (s *FeedSource) Name() string { return s.cfg.Name }
+
+func (s *FeedSource) Enabled() bool { return s.cfg.URL != "" }
+
+// ListPrefix is the feed's id prefix when it is configured as a full list
+func (s *FeedSource) ListPrefix() string {
+	if !s.cfg.FullList {
+		return ""
+	}
+	return s.cfg.IDPrefix + "-"
+}
+
+func (s *FeedSource) Fetch(ctx context.Context, opts Options) ([]storage.JobRow, error) {
+	return Feed(ctx, opts.conditional(), s.cfg, opts.JobCount)
+}
+
+// Feed parses a single RSS/Atom feed into jobs according to cfg, returning
+// ErrNotModified when cache says it hasn't changed. cache may be nil.
+func Feed(ctx context.Context, cache *ConditionalCache, cfg FeedConfig, jobCount int) ([]storage.JobRow, error) {
+	resp, err := cache.Get(ctx, cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.Name, err)
+	}
+	defer resp.Body.Close()
+
+	feed, err := gofeed.NewParser().Parse(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.Name, &DecodeError{Err: err})
+	}
+	cache.MarkDecoded(cfg.URL)
+
+	var jobs []storage.JobRow
+	for _, item := range feed.Items {
+		title, company := splitFeedTitle(item.Title, cfg.TitleSplit)
+		if mapped := feedField(item, cfg.Fields[FeedFieldCompany]); mapped != "" {
+			company = mapped
+		}
+
+		// Keep the HTML as given, it is rendered to Markdown and text before storing
+		content := item.Description
+		if content == "" {
+			content = item.Content
+		}
+
+		// gofeed parses the common date formats; anything it couldn't gets a
+		// second try with the source's timezone
+		publishedRaw := item.Published
+		if publishedRaw == "" {
+			publishedRaw = item.Updated
+		}
+		var publishedAt time.Time
+		switch {
+		case item.PublishedParsed != nil:
+			publishedAt = item.PublishedParsed.UTC()
+		case item.UpdatedParsed != nil:
+			publishedAt = item.UpdatedParsed.UTC()
+		default:
+			publishedAt = parsePublished(cfg.Source, publishedRaw)
+		}
+
+		guid := item.GUID
+		if guid == "" {
+			guid = item.Link
+		}
+
+		salaryRaw := feedField(item, cfg.Fields[FeedFieldSalary])
+		jobs = append(jobs, withSalary(storage.JobRow{
+			ID:            cfg.IDPrefix + "-" + guid,
+			Source:        cfg.Source,
+			Title:         title,
+			Company:       company,
+			Description:   content,
+			Location:      feedField(item, cfg.Fields[FeedFieldLocation]),
+			WorkplaceType: cfg.Workplace,
+			WorkType:      feedField(item, cfg.Fields[FeedFieldWorkType]),
+			SourceTags:    item.Categories,
+			URL:           item.Link,
+			PublishedAt:   publishedAt,
+			PublishedRaw:  publishedRaw,
+			SalaryRaw:     salaryRaw,
+		}, salary.Parse(salaryRaw)))
+
+		// Limit results if jobCount is specified
+		if jobCount > 0 && len(jobs) >= jobCount {
+			break
+		}
+	}
+	return jobs, nil
+}
+
+// splitFeedTitle splits "Company: Title" style titles, returning the title unchanged
+// when no rule is configured or the separator is missing
+func splitFeedTitle(raw string, rule *TitleSplit) (title, company string) {
+	if rule == nil || rule.Separator == "" {
+		return raw, ""
+	}
+
+	if rule.CompanyFirst {
+		if idx := strings.Index(raw, rule.Separator); idx > 0 {
+			return strings.TrimSpace(raw[idx+len(rule.Separator):]), strings.TrimSpace(raw[:idx])
+		}
+		return raw, ""
+	}
+
+	if idx := strings.LastIndex(raw, rule.Separator); idx > 0 {
+		return strings.TrimSpace(raw[:idx]), strings.TrimSpace(raw[idx+len(rule.Separator):])
+	}
+	return raw, ""
+}
+
+// feedField resolves a configured element name against an item
+func feedField(item *gofeed.Item, element string) string {
+	switch element {
+	case "":
+		return ""
+	case "author":
+		if item.Author != nil {
+			return strings.TrimSpace(item.Author.Name)
+		}
+		return ""
+	case "category":
+		if len(item.Categories) > 0 {
+			return strings.TrimSpace(item.Categories[0])
+		}
+		return ""
+	}
+
+	// Namespaced elements ("job_listing:location") live in Extensions
+	if prefix, name, ok := strings.Cut(element, ":"); ok {
+		if exts := item.Extensions[prefix][name]; len(exts) > 0 {
+			return strings.TrimSpace(exts[0].Value)
+		}
+		return ""
+	}
+
+	return strings.TrimSpace(item.Custom[element])
+}
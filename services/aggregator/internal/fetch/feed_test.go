@@ -0,0 +1,22 @@
+package fetch
+
+import "testing"
+
+func TestSplitFeedTitle(t *testing.T) {
+	tests := []struct {
+		raw            string
+		rule           *TitleSplit
+		title, company string
+	}{
+		{"Acme: Go Engineer", &TitleSplit{Separator: ":", CompanyFirst: true}, "Go Engineer", "Acme"},
+		{"Go Engineer at Acme", &TitleSplit{Separator: " at "}, "Go Engineer", "Acme"},
+		{"Go Engineer", &TitleSplit{Separator: ":", CompanyFirst: true}, "Go Engineer", ""},
+		{"Acme: Go Engineer", nil, "Acme: Go Engineer", ""},
+	}
+	for _, tt := range tests {
+		title, company := splitFeedTitle(tt.raw, tt.rule)
+		if title != tt.title || company != tt.company {
+			t.Errorf("splitFeedTitle(%q) = %q, %q, want %q, %q", tt.raw, title, company, tt.title, tt.company)
+		}
+	}
+}
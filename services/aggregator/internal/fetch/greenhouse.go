@@ -0,0 +1,101 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/storage"
+)
+
+type greenhouseResp struct {
+	Jobs []struct {
+		ID          int64  `json:"id"`
+		Title       string `json:"title"`
+		CompanyName string `json:"company_name"`
+		Content     string `json:"content"` // HTML-escaped HTML
+		AbsoluteURL string `json:"absolute_url"`
+		UpdatedAt   string `json:"updated_at"`      // "2025-08-06T08:00:30-04:00"
+		FirstPosted string `json:"first_published"` // only present on newer boards
+		Location    struct {
+			Name string `json:"name"`
+		} `json:"location"`
+		Departments []struct {
+			Name string `json:"name"`
+		} `json:"departments"`
+	} `json:"jobs"`
+}
+
+// GreenhouseSource pulls postings from the public job boards of configured companies
+type GreenhouseSource struct {
+	baseURL string
+	boards  []string
+}
+
+// NewGreenhouseSource creates a Greenhouse source for the given board tokens,
+// empty baseURL uses the public boards API
+func NewGreenhouseSource(baseURL string, boards []string) *GreenhouseSource {
+	return &GreenhouseSource{baseURL: baseURL, boards: boards}
+}
+
+func (s *GreenhouseSource) Name() string { return "greenhouse" }
+
+// Enabled returns true if at least one board token is configured
+func (s *GreenhouseSource) Enabled() bool { return len(s.boards) > 0 }
+
+func (s *GreenhouseSource) Fetch(ctx context.Context, opts Options) ([]storage.JobRow, error) {
+	return FetchCompanies(ctx, s.Name(), s.boards, opts, func(ctx context.Context, board string) ([]storage.JobRow, error) {
+		return Greenhouse(ctx, s.baseURL, board)
+	})
+}
+
+// Greenhouse fetches every posting on a single company board. board is the
+// board token, optionally with the company name to use when the API has none
+// ("acme=Acme Inc").
+func Greenhouse(ctx context.Context, baseURL, board string) ([]storage.JobRow, error) {
+	if baseURL == "" {
+		baseURL = "https://boards-api.greenhouse.io"
+	}
+	board, name := companyBoard(board)
+	endpt := fmt.Sprintf("%s/v1/boards/%s/jobs?content=true",
+		strings.TrimRight(baseURL, "/"), url.PathEscape(board))
+
+	var data greenhouseResp
+	if err := getJSON(ctx, endpt, &data); err != nil {
+		return nil, fmt.Errorf("greenhouse %s: %w", board, err)
+	}
+
+	out := make([]storage.JobRow, 0, len(data.Jobs))
+	for _, j := range data.Jobs {
+		company := j.CompanyName
+		if company == "" {
+			company = name
+		}
+
+		workType := ""
+		if len(j.Departments) > 0 {
+			workType = j.Departments[0].Name
+		}
+
+		publishedAt := j.FirstPosted
+		if publishedAt == "" {
+			publishedAt = j.UpdatedAt
+		}
+
+		out = append(out, storage.JobRow{
+			ID:           fmt.Sprintf("greenhouse-%s-%d", strings.ToLower(board), j.ID),
+			Source:       "greenhouse",
+			Title:        j.Title,
+			Company:      company,
+			Description:  html.UnescapeString(j.Content), // Greenhouse escapes the HTML body
+			Location:     j.Location.Name,
+			WorkType:     workType,
+			URL:          j.AbsoluteURL,
+			PublishedAt:  parsePublished("greenhouse", publishedAt),
+			PublishedRaw: publishedAt,
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,75 @@
+package fetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGreenhouse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/boards/acme-corp/jobs" || r.URL.Query().Get("content") != "true" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"jobs": [
+			{"id": 101, "title": "Go Engineer", "company_name": "Acme Corporation",
+			 "content": "&lt;p&gt;Build &lt;b&gt;APIs&lt;/b&gt;&lt;/p&gt;",
+			 "absolute_url": "https://boards.greenhouse.io/acme-corp/jobs/101",
+			 "updated_at": "2026-10-12T08:00:00-04:00", "first_published": "2026-10-01T09:30:00-04:00",
+			 "location": {"name": "Remote - US"}, "departments": [{"name": "Engineering"}]},
+			{"id": 102, "title": "Designer", "content": "",
+			 "absolute_url": "https://boards.greenhouse.io/acme-corp/jobs/102",
+			 "updated_at": "2026-10-12T08:00:00-04:00", "location": {"name": "Berlin"}}
+		]}`))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		board, company string
+	}{
+		{"acme-corp", "Acme Corp"},
+		{"acme-corp=Acme Inc", "Acme Inc"},
+	}
+	for _, tt := range tests {
+		jobs, err := Greenhouse(context.Background(), srv.URL, tt.board)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(jobs) != 2 {
+			t.Fatalf("got %d jobs, want 2", len(jobs))
+		}
+
+		first := jobs[0]
+		if first.ID != "greenhouse-acme-corp-101" || first.Source != "greenhouse" {
+			t.Errorf("ID %q, source %q", first.ID, first.Source)
+		}
+		if first.Company != "Acme Corporation" {
+			t.Errorf("Company = %q, want the API's company name", first.Company)
+		}
+		if first.Description != "<p>Build <b>APIs</b></p>" {
+			t.Errorf("Description = %q, want the unescaped HTML", first.Description)
+		}
+		if first.Location != "Remote - US" || first.WorkType != "Engineering" {
+			t.Errorf("location %q, work type %q", first.Location, first.WorkType)
+		}
+		if want := time.Date(2026, 10, 1, 13, 30, 0, 0, time.UTC); !first.PublishedAt.Equal(want) {
+			t.Errorf("PublishedAt = %v, want first_published %v", first.PublishedAt, want)
+		}
+
+		// No company_name or first_published on older boards
+		second := jobs[1]
+		if second.Company != tt.company {
+			t.Errorf("board %q: Company = %q, want %q", tt.board, second.Company, tt.company)
+		}
+		if want := time.Date(2026, 10, 12, 12, 0, 0, 0, time.UTC); !second.PublishedAt.Equal(want) {
+			t.Errorf("PublishedAt = %v, want updated_at %v", second.PublishedAt, want)
+		}
+	}
+
+	if _, err := Greenhouse(context.Background(), srv.URL, "missing"); Kind(err) != KindStatus {
+		t.Errorf("unknown board: error %v, want a status error", err)
+	}
+}
@@ -0,0 +1,226 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/logger"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/salary"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/storage"
+	"go.uber.org/zap"
+)
+
+// hnItem is a story or comment from the HN Algolia items API, with its full reply tree
+type hnItem struct {
+	ID        int64    `json:"id"`
+	CreatedAt string   `json:"created_at"` // RFC3339
+	Type      string   `json:"type"`
+	Author    string   `json:"author"`
+	Title     string   `json:"title"`
+	Text      string   `json:"text"` // HTML with escaped entities
+	Children  []hnItem `json:"children"`
+}
+
+type hnSearchResp struct {
+	Hits []struct {
+		ObjectID string `json:"objectID"`
+		Title    string `json:"title"`
+	} `json:"hits"`
+}
+
+var (
+	hnTagRegex       = regexp.MustCompile(`<[^>]*>`)
+	hnHrefRegex      = regexp.MustCompile(`href="([^"]+)"`)
+	hnURLRegex       = regexp.MustCompile(`https?://\S+`)
+	hnCurrencyRegex  = regexp.MustCompile(`[$€£]|\b(USD|EUR|GBP|CAD|AUD)\b|\d+\s*[kK]\b`)
+	hnRoleRegex      = regexp.MustCompile(`(?i)\b(engineer|developer|designer|manager|scientist|analyst|architect|lead|sre|devops|founding|head of|director|recruiter|intern|researcher|programmer|product|marketing|sales)\b`)
+	hnWorkplaceWords = regexp.MustCompile(`(?i)\b(remote|onsite|on-site|hybrid|or|and|only|friendly|ok)\b`)
+	hnWorkTypeRegex  = regexp.MustCompile(`(?i)^(full[- ]?time|part[- ]?time|contract(or)?|freelance|intern(ship)?)(\s*(/|,|or)\s*(full[- ]?time|part[- ]?time|contract(or)?|freelance|intern(ship)?))*$`)
+)
+
+// HackerNewsSource ingests the top-level comments of a monthly "Ask HN: Who is hiring?" thread
+type HackerNewsSource struct {
+	baseURL  string
+	threadID int64
+}
+
+// NewHackerNewsSource creates an HN source for threadID, 0 picks the latest
+// thread posted by the whoishiring account. Empty baseURL uses the public Algolia API.
+func NewHackerNewsSource(baseURL string, threadID int64) *HackerNewsSource {
+	return &HackerNewsSource{baseURL: baseURL, threadID: threadID}
+}
+
+func (s *HackerNewsSource) Name() string { return "hn" }
+
+func (s *HackerNewsSource) Enabled() bool { return true }
+
+func (s *HackerNewsSource) Fetch(ctx context.Context, opts Options) ([]storage.JobRow, error) {
+	threadID := s.threadID
+	if threadID == 0 {
+		latest, err := latestWhoIsHiringThread(ctx, s.apiURL())
+		if err != nil {
+			return nil, err
+		}
+		threadID = latest
+	}
+	logger.Info("Fetching HN Who is hiring thread", zap.Int64("threadID", threadID))
+
+	jobs, err := HackerNews(ctx, s.apiURL(), threadID)
+	if err != nil {
+		return nil, err
+	}
+	return limitJobs(jobs, opts.JobCount), nil
+}
+
+func (s *HackerNewsSource) apiURL() string {
+	if s.baseURL == "" {
+		return "https://hn.algolia.com"
+	}
+	return strings.TrimRight(s.baseURL, "/")
+}
+
+// latestWhoIsHiringThread finds the newest "Who is hiring?" story by the whoishiring account
+func latestWhoIsHiringThread(ctx context.Context, baseURL string) (int64, error) {
+	endpt := baseURL + "/api/v1/search_by_date?tags=story,author_whoishiring&hitsPerPage=10"
+
+	var data hnSearchResp
+	if err := getJSON(ctx, endpt, &data); err != nil {
+		return 0, fmt.Errorf("hn: failed to search threads: %w", err)
+	}
+
+	// whoishiring also posts "Who wants to be hired?" and "Freelancer?" threads
+	for _, hit := range data.Hits {
+		if strings.Contains(strings.ToLower(hit.Title), "who is hiring") {
+			return strconv.ParseInt(hit.ObjectID, 10, 64)
+		}
+	}
+	return 0, fmt.Errorf("hn: no Who is hiring thread found")
+}
+
+// HackerNews turns every top-level comment of a Who is hiring thread into a job
+func HackerNews(ctx context.Context, baseURL string, threadID int64) ([]storage.JobRow, error) {
+	var thread hnItem
+	if err := getJSON(ctx, fmt.Sprintf("%s/api/v1/items/%d", baseURL, threadID), &thread); err != nil {
+		return nil, fmt.Errorf("hn: failed to fetch thread %d: %w", threadID, err)
+	}
+
+	var jobs []storage.JobRow
+	skipped := 0
+	// Only direct children of the story are postings, replies are discussion
+	for _, c := range thread.Children {
+		if c.Type != "comment" || c.Author == "" || c.Text == "" {
+			continue // deleted or dead comment
+		}
+		job, ok := parseHNPosting(c)
+		if !ok {
+			skipped++
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+
+	logger.Info("Parsed HN Who is hiring thread",
+		zap.Int64("threadID", threadID),
+		zap.Int("postings", len(jobs)),
+		zap.Int("skipped", skipped))
+	return jobs, nil
+}
+
+// parseHNPosting reads the conventional "Company | Role | Location | REMOTE | Salary | URL"
+// first line of a comment. Comments without a pipe-separated header are not postings.
+func parseHNPosting(c hnItem) (storage.JobRow, bool) {
+	header := c.Text
+	if idx := strings.Index(header, "<p>"); idx >= 0 {
+		header = header[:idx]
+	}
+	headerHTML := header
+	header = html.UnescapeString(hnTagRegex.ReplaceAllString(header, " "))
+
+	fields := strings.Split(header, "|")
+	if len(fields) < 2 {
+		return storage.JobRow{}, false
+	}
+
+	company := strings.TrimSpace(fields[0])
+	var title, location, workType, link, workplace, payText string
+	var pay salary.Salary
+	var leftovers []string
+
+	for _, raw := range fields[1:] {
+		field := strings.TrimSpace(raw)
+		if field == "" {
+			continue
+		}
+		upper := strings.ToUpper(field)
+
+		switch {
+		case hnURLRegex.MatchString(field) && link == "":
+			link = hnURLRegex.FindString(field)
+		case strings.Contains(upper, "REMOTE") || strings.Contains(upper, "ONSITE") ||
+			strings.Contains(upper, "ON-SITE") || strings.Contains(upper, "HYBRID"):
+			workplace = hnWorkplace(upper, workplace)
+			// "REMOTE (US)" also carries a location
+			rest := strings.NewReplacer("(", " ", ")", " ").Replace(hnWorkplaceWords.ReplaceAllString(field, " "))
+			if rest = strings.Trim(strings.Join(strings.Fields(rest), " "), " -,/;"); len(rest) > 1 && location == "" {
+				location = rest
+			}
+		case hnCurrencyRegex.MatchString(field) && pay.IsZero():
+			pay, payText = salary.Parse(field), field
+		case hnWorkTypeRegex.MatchString(field):
+			workType = field
+		case title == "" && hnRoleRegex.MatchString(field):
+			title = field
+		default:
+			leftovers = append(leftovers, field)
+		}
+	}
+
+	// Fall back to positional fields when keyword matching didn't find them
+	if title == "" && len(leftovers) > 0 {
+		title, leftovers = leftovers[0], leftovers[1:]
+	}
+	if location == "" && len(leftovers) > 0 {
+		location = leftovers[0]
+	}
+	if title == "" {
+		return storage.JobRow{}, false
+	}
+
+	// Links in the header are anchors, prefer their href over the truncated text
+	if m := hnHrefRegex.FindStringSubmatch(headerHTML); m != nil {
+		link = html.UnescapeString(m[1])
+	}
+	if link == "" {
+		link = fmt.Sprintf("https://news.ycombinator.com/item?id=%d", c.ID)
+	}
+
+	return withSalary(storage.JobRow{
+		ID:           fmt.Sprintf("hn-%d", c.ID),
+		Source:       "hackernews",
+		Title:        title,
+		Company:      company,
+		Description:  c.Text,
+		Location:     workplaceLocation(workplace, location),
+		WorkType:     workType,
+		URL:          link,
+		PublishedAt:  parsePublished("hackernews", c.CreatedAt),
+		PublishedRaw: c.CreatedAt,
+		SalaryRaw:    payText,
+	}, pay), true
+}
+
+// hnWorkplace merges workplace markers: any REMOTE wins, otherwise HYBRID, otherwise ONSITE
+func hnWorkplace(upper, current string) string {
+	switch {
+	case strings.Contains(upper, "REMOTE") || current == "remote":
+		return "remote"
+	case strings.Contains(upper, "HYBRID") || current == "hybrid":
+		return "hybrid"
+	default:
+		return "onsite"
+	}
+}
@@ -0,0 +1,96 @@
+package fetch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseHNPosting(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		ok        bool
+		title     string
+		company   string
+		location  string
+		workType  string
+		url       string
+		salaryMin int
+		salaryMax int
+		currency  string
+	}{
+		{
+			name:      "full header",
+			text:      `Acme | Senior Backend Engineer | Berlin | REMOTE (EU) | €80k-€100k | Full-time | <a href="https:&#x2F;&#x2F;acme.example&#x2F;jobs" rel="nofollow">https:&#x2F;&#x2F;acme.example&#x2F;j...</a><p>We build payments.`,
+			ok:        true,
+			title:     "Senior Backend Engineer",
+			company:   "Acme",
+			location:  "Remote - EU",
+			workType:  "Full-time",
+			url:       "https://acme.example/jobs",
+			salaryMin: 80000,
+			salaryMax: 100000,
+			currency:  "EUR",
+		},
+		{
+			name:     "onsite without keywords uses positional fields",
+			text:     `Globex | Growth Hacker | New York, NY | ONSITE<p>Details below.`,
+			ok:       true,
+			title:    "Growth Hacker",
+			company:  "Globex",
+			location: "On-site - New York, NY",
+			url:      "https://news.ycombinator.com/item?id=42",
+		},
+		{
+			name:     "hybrid with a plain url",
+			text:     `Initech | Data Scientist | HYBRID | https://initech.example/careers`,
+			ok:       true,
+			title:    "Data Scientist",
+			company:  "Initech",
+			location: "Hybrid",
+			url:      "https://initech.example/careers",
+		},
+		{
+			name: "reply without a header",
+			text: `Is this role open to contractors?<p>Thanks!`,
+		},
+		{
+			name: "pipes only in the body",
+			text: `We're hiring!<p>Go | Rust | Postgres`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job, ok := parseHNPosting(hnItem{ID: 42, CreatedAt: "2026-10-01T15:00:00Z", Text: tt.text})
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v (%+v)", ok, tt.ok, job)
+			}
+			if !ok {
+				return
+			}
+			if job.ID != "hn-42" || job.Source != "hackernews" {
+				t.Errorf("ID %q, source %q", job.ID, job.Source)
+			}
+			if job.Title != tt.title || job.Company != tt.company {
+				t.Errorf("got %q at %q, want %q at %q", job.Title, job.Company, tt.title, tt.company)
+			}
+			if job.Location != tt.location {
+				t.Errorf("Location = %q, want %q", job.Location, tt.location)
+			}
+			if job.WorkType != tt.workType {
+				t.Errorf("WorkType = %q, want %q", job.WorkType, tt.workType)
+			}
+			if job.URL != tt.url {
+				t.Errorf("URL = %q, want %q", job.URL, tt.url)
+			}
+			if job.SalaryMin != tt.salaryMin || job.SalaryMax != tt.salaryMax || job.SalaryCurrency != tt.currency {
+				t.Errorf("salary = %d-%d %q, want %d-%d %q",
+					job.SalaryMin, job.SalaryMax, job.SalaryCurrency, tt.salaryMin, tt.salaryMax, tt.currency)
+			}
+			if want := time.Date(2026, 10, 1, 15, 0, 0, 0, time.UTC); !job.PublishedAt.Equal(want) {
+				t.Errorf("PublishedAt = %v, want %v", job.PublishedAt, want)
+			}
+		})
+	}
+}
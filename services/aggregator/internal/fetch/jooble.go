@@ -5,12 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/logger"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/salary"
 	"github.com/sanchitb23/remote-job-radar/aggregator/internal/storage"
-	"github.com/sanchitb23/remote-job-radar/aggregator/internal/utils"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 )
 
 type JoobleResp struct {
@@ -26,6 +30,276 @@ type JoobleResp struct {
 	} `json:"jobs"`
 }
 
+// JoobleSource fans out weighted keyword/location queries against the Jooble API
+type JoobleSource struct {
+	apiKey      string
+	concurrency int
+	timeout     time.Duration
+	planFile    string
+	skills      []string
+}
+
+// NewJoobleSource creates a Jooble source limited to concurrency parallel queries
+// and timeout for the whole run. Queries come from planFile, re-read on every
+// fetch so edits apply without a redeploy; empty planFile uses DefaultJoobleQueryPlan.
+// skills feeds the plan's from_skills block.
+func NewJoobleSource(apiKey string, concurrency int, timeout time.Duration, planFile string, skills []string) *JoobleSource {
+	return &JoobleSource{apiKey: apiKey, concurrency: concurrency, timeout: timeout, planFile: planFile, skills: skills}
+}
+
+func (s *JoobleSource) Name() string { return "jooble" }
+
+// Enabled returns true if Jooble API key is configured
+func (s *JoobleSource) Enabled() bool { return s.apiKey != "" }
+
+// searchQueries loads the current query plan, falling back to the default plan
+// if the plan file can't be read
+func (s *JoobleSource) searchQueries() []JoobleQuery {
+	plan := DefaultJoobleQueryPlan()
+	if s.planFile != "" {
+		loaded, err := LoadJoobleQueryPlan(s.planFile)
+		if err != nil {
+			logger.Error("Failed to load Jooble query plan, using default plan",
+				zap.String("file", s.planFile),
+				zap.Error(err))
+		} else {
+			plan = loaded
+		}
+	}
+	return plan.Expand(s.skills)
+}
+
+// getAdaptiveSearchStrategy picks the highest-weight queries whose page budgets
+// cover jobCount, and the concurrency to run them with
+func (s *JoobleSource) getAdaptiveSearchStrategy(jobCount, maxPages int) (int, []JoobleQuery) {
+	queries := s.searchQueries()
+
+	// No job count limit: use all queries with full concurrency
+	if jobCount <= 0 || len(queries) == 0 {
+		return s.concurrency, queries
+	}
+
+	totalPagesNeeded := (jobCount + 49) / 50 // Ceiling division
+	budget := 0
+	var selected []JoobleQuery
+	for _, q := range queries {
+		selected = append(selected, q)
+		budget += queryPages(q, maxPages)
+		if budget >= totalPagesNeeded {
+			break
+		}
+	}
+
+	concurrency := s.concurrency
+	if len(selected) < concurrency {
+		concurrency = len(selected)
+	}
+	logger.Info("Selected Jooble queries for job count",
+		zap.Int("jobCount", jobCount),
+		zap.Int("totalPagesNeeded", totalPagesNeeded),
+		zap.Int("pageBudget", budget),
+		zap.Int("selectedQueries", len(selected)),
+		zap.Int("plannedQueries", len(queries)))
+	return concurrency, selected
+}
+
+// queryPages is the page budget of a query, capped by maxPages
+func queryPages(q JoobleQuery, maxPages int) int {
+	if q.Pages > 0 && (maxPages <= 0 || q.Pages < maxPages) {
+		return q.Pages
+	}
+	if maxPages <= 0 {
+		return 1
+	}
+	return maxPages
+}
+
+// Fetch fetches jobs from Jooble API with pagination & multiple keywords/locations
+func (s *JoobleSource) Fetch(ctx context.Context, opts Options) ([]storage.JobRow, error) {
+	jobCount := opts.JobCount
+	logger.Info("Fetching jobs from Jooble API", zap.Int("jobCount", jobCount))
+
+	// Pre-allocate slice with estimated capacity to reduce memory allocations
+	estimatedCapacity := jobCount
+	if estimatedCapacity == 0 {
+		estimatedCapacity = opts.MaxPages * 50 * 4 // maxPages * pageSize * a few keyword/location combinations
+	}
+	allJoobleJobs := make([]storage.JobRow, 0, estimatedCapacity)
+
+	// Shared deduplication map, guarded by mu
+	seen := make(map[string]struct{}, estimatedCapacity)
+
+	// Get adaptive search strategy based on job count
+	concurrency, searchQueries := s.getAdaptiveSearchStrategy(jobCount, opts.MaxPages)
+	logger.Info("Using adaptive search strategy",
+		zap.Int("concurrency", concurrency),
+		zap.Int("searchQueries", len(searchQueries)),
+		zap.Int("jobCount", jobCount),
+		zap.Int("maxPages", opts.MaxPages))
+	if len(searchQueries) == 0 {
+		logger.Warn("Jooble query plan is empty, nothing to fetch")
+		return nil, nil
+	}
+
+	// Use buffered channel for concurrent fetching with adaptive concurrency
+	semaphore := make(chan struct{}, concurrency)
+
+	// Create a context with timeout for the entire operation
+	operationCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	// Use errgroup for better error handling and cancellation
+	g, gCtx := errgroup.WithContext(operationCtx)
+
+	// Track successful fetches for early termination
+	var totalFetched int32
+	var mu sync.Mutex
+
+	for _, query := range searchQueries {
+		// Early termination if we have enough jobs
+		if jobCount > 0 && atomic.LoadInt32(&totalFetched) >= int32(jobCount) {
+			break
+		}
+
+		query := query // Capture for goroutine
+		g.Go(func() error {
+			return s.fetchQuery(gCtx, query.Keyword, query.Location, queryPages(query, opts.MaxPages), jobCount,
+				&allJoobleJobs, seen, &totalFetched, &mu, semaphore, opts.cursors())
+		})
+	}
+
+	// Wait for all goroutines to complete
+	if err := g.Wait(); err != nil {
+		if Kind(err) == KindAuth {
+			return nil, err
+		}
+		logger.Warn("Some Jooble queries failed", zap.Error(err))
+		// Continue with partial results
+	}
+
+	// Trim if overshot
+	allJoobleJobs = limitJobs(allJoobleJobs, jobCount)
+
+	logger.Info("Total jobs fetched from Jooble",
+		zap.Int("count", len(allJoobleJobs)),
+		zap.Int("uniqueJobs", len(seen)))
+
+	return allJoobleJobs, nil
+}
+
+// fetchQuery handles fetching for a single keyword/location combination. Each
+// combination keeps its own cursor; results are ordered by relevance, not
+// date, so the cursor only drops postings an earlier run stored and paging
+// goes on up to maxPages.
+func (s *JoobleSource) fetchQuery(ctx context.Context, keyword, location string, maxPages, jobCount int,
+	allJobs *[]storage.JobRow, seen map[string]struct{}, totalFetched *int32, mu *sync.Mutex, semaphore chan struct{},
+	cursors *Cursors) error {
+
+	// Acquire semaphore to limit concurrency
+	select {
+	case semaphore <- struct{}{}:
+		defer func() { <-semaphore }()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	logger.Info("Fetching Jooble jobs",
+		zap.String("keyword", keyword),
+		zap.String("location", location))
+
+	var queryJobs []storage.JobRow
+	seenLocal := make(map[string]struct{}) // Local deduplication for this query
+
+	cursorKey := "jooble:" + strings.ToLower(keyword) + "|" + strings.ToLower(location)
+	since := cursors.Since(ctx, cursorKey)
+	complete := true
+
+	for page := 1; page <= maxPages; page++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		// Check if we've already reached the global job count limit
+		if jobCount > 0 && atomic.LoadInt32(totalFetched) >= int32(jobCount) {
+			break
+		}
+
+		// Per-page timeout, transient failures are already retried by the HTTP client
+		pageCtx, cancel := context.WithTimeout(ctx, defaultPageTimeout)
+		joobleJobs, err := Jooble(pageCtx, page, s.apiKey, keyword, location, jobCount)
+		cancel()
+
+		if err != nil {
+			logger.Error("Jooble fetch error",
+				zap.Error(err),
+				zap.String("kind", string(Kind(err))),
+				zap.String("keyword", keyword),
+				zap.String("location", location),
+				zap.Int("page", page))
+			if Kind(err) == KindAuth {
+				// A bad API key fails every query, cancel the rest
+				return err
+			}
+			complete = false
+			break
+		}
+
+		logger.Debug("Retrieved jobs from Jooble",
+			zap.Int("count", len(joobleJobs)),
+			zap.String("keyword", keyword),
+			zap.String("location", location),
+			zap.Int("page", page))
+
+		// Local deduplication
+		for _, job := range joobleJobs {
+			if _, exists := seenLocal[job.ID]; exists {
+				continue
+			}
+			if !since.IsZero() && !isNewer(job, since) {
+				continue
+			}
+			seenLocal[job.ID] = struct{}{}
+			queryJobs = append(queryJobs, job)
+		}
+
+		// Stop if no more results or if we have enough jobs locally
+		if len(joobleJobs) < 50 {
+			break
+		}
+
+		// Check again if we've reached the job count limit after processing this page
+		if jobCount > 0 && atomic.LoadInt32(totalFetched) >= int32(jobCount) {
+			break
+		}
+	}
+
+	// A failed page leaves the cursor put so the missed postings are fetched next run
+	if complete {
+		cursors.Advance(cursorKey, queryJobs)
+	}
+
+	// Merge results with thread-safe access and early termination
+	if len(queryJobs) > 0 {
+		mu.Lock()
+		for _, job := range queryJobs {
+			// Check if we've reached the job count limit before adding more jobs
+			if jobCount > 0 && atomic.LoadInt32(totalFetched) >= int32(jobCount) {
+				break
+			}
+			if _, exists := seen[job.ID]; !exists {
+				seen[job.ID] = struct{}{}
+				*allJobs = append(*allJobs, job)
+				atomic.AddInt32(totalFetched, 1)
+			}
+		}
+		mu.Unlock()
+	}
+
+	return nil
+}
+
 func Jooble(ctx context.Context, page int, apiKey string, keywords string, location string, jobCount int) ([]storage.JobRow, error) {
 	if apiKey == "" {
 		return nil, fmt.Errorf("jooble API key is required")
@@ -64,40 +338,19 @@ func Jooble(ctx context.Context, page int, apiKey string, keywords string, locat
 	req.Header.Set("Content-Type", "application/json")
 
 	// Make the request
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := HTTPClient().Do(req)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("jooble: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Check for non-200 status codes
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("jooble: status %d", resp.StatusCode)
-	}
-
 	var jr JoobleResp
-	if err := json.NewDecoder(resp.Body).Decode(&jr); err != nil {
-		return nil, fmt.Errorf("failed to decode Jooble response: %w", err)
+	if err := decodeJSON(resp, &jr); err != nil {
+		return nil, fmt.Errorf("jooble: %w", err)
 	}
 
 	var jobs []storage.JobRow
 	for _, j := range jr.Jobs {
-		// Parse salary if available
-		salaryMin, salaryMax := parseJoobleSalary(j.Salary)
-
-		// Convert HTML snippet to plain text using utility function
-		description, _ := utils.PreprocessText(j.Snippet, 0)
-
-		// Parse updated time
-		publishedAt := ""
-		if j.Updated != "" {
-			if parsed, err := time.Parse(time.RFC3339, j.Updated); err == nil {
-				publishedAt = parsed.Format(time.RFC3339)
-			} else {
-				// Try alternative time formats if RFC3339 fails
-				publishedAt = j.Updated
-			}
-		}
 
 		// Convert ID to string safely
 		jobID := "jooble-" + j.ID.String()
@@ -108,72 +361,19 @@ func Jooble(ctx context.Context, page int, apiKey string, keywords string, locat
 			continue
 		}
 
-		jobs = append(jobs, storage.JobRow{
-			ID:          jobID,
-			Source:      "jooble",
-			Title:       j.Title,
-			Company:     j.Company,
-			Description: description,
-			Location:    j.Location,
-			WorkType:    "", // Jooble doesn't provide work type in basic response
-			URL:         j.Link,
-			PublishedAt: publishedAt,
-			SalaryMin:   salaryMin,
-			SalaryMax:   salaryMax,
-		})
+		jobs = append(jobs, withSalary(storage.JobRow{
+			ID:           jobID,
+			Source:       "jooble",
+			Title:        j.Title,
+			Company:      j.Company,
+			Description:  j.Snippet,
+			Location:     j.Location,
+			WorkType:     "", // Jooble doesn't provide work type in basic response
+			URL:          j.Link,
+			PublishedAt:  parsePublished("jooble", j.Updated),
+			PublishedRaw: j.Updated,
+			SalaryRaw:    j.Salary,
+		}, salary.Parse(j.Salary)))
 	}
 	return jobs, nil
 }
-
-// parseJoobleSalary parses salary string like "50k-80k USD" or "100k USD" to min/max integers
-func parseJoobleSalary(salaryStr string) (minSal, maxSal int) {
-	if salaryStr == "" {
-		return 0, 0
-	}
-
-	// Remove currency and common suffixes
-	salaryStr = strings.ToUpper(salaryStr)
-	salaryStr = strings.ReplaceAll(salaryStr, "USD", "")
-	salaryStr = strings.ReplaceAll(salaryStr, "EUR", "")
-	salaryStr = strings.ReplaceAll(salaryStr, "GBP", "")
-	salaryStr = strings.TrimSpace(salaryStr)
-
-	// Handle ranges like "50k-80k"
-	if strings.Contains(salaryStr, "-") {
-		parts := strings.Split(salaryStr, "-")
-		if len(parts) == 2 {
-			minSal = parseSalaryAmount(parts[0])
-			maxSal = parseSalaryAmount(parts[1])
-		}
-	} else {
-		// Single amount like "100k"
-		amount := parseSalaryAmount(salaryStr)
-		minSal = amount
-		maxSal = amount
-	}
-
-	return minSal, maxSal
-}
-
-// parseSalaryAmount parses individual salary amounts like "50k", "100k", "1.5m"
-func parseSalaryAmount(amountStr string) int {
-	amountStr = strings.TrimSpace(amountStr)
-	amountStr = strings.ToLower(amountStr)
-
-	var multiplier int
-	if strings.HasSuffix(amountStr, "k") {
-		multiplier = 1000
-		amountStr = strings.TrimSuffix(amountStr, "k")
-	} else if strings.HasSuffix(amountStr, "m") {
-		multiplier = 1000000
-		amountStr = strings.TrimSuffix(amountStr, "m")
-	} else {
-		multiplier = 1
-	}
-
-	if amount, err := strconv.ParseFloat(amountStr, 64); err == nil {
-		return int(amount * float64(multiplier))
-	}
-
-	return 0
-}
@@ -0,0 +1,124 @@
+package fetch
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// JoobleQueryPlan lists the keyword searches run against Jooble on each fetch
+type JoobleQueryPlan struct {
+	// Locations is the default location set for queries that don't list their own
+	Locations []string          `yaml:"locations"`
+	Queries   []JoobleQuerySpec `yaml:"queries"`
+	// FromSkills adds one query per skill in the skills file
+	FromSkills *JoobleQuerySpec `yaml:"from_skills"`
+}
+
+// JoobleQuerySpec is a weighted keyword searched in every one of its locations
+type JoobleQuerySpec struct {
+	Keyword   string   `yaml:"keyword"`
+	Weight    int      `yaml:"weight"`    // higher weight = search first
+	Pages     int      `yaml:"pages"`     // page budget per location, 0 uses FETCHER_MAX_PAGE_NUM
+	Locations []string `yaml:"locations"` // empty uses the plan's default locations
+}
+
+// JoobleQuery is a single keyword/location search issued against Jooble
+type JoobleQuery struct {
+	Keyword  string
+	Location string
+	Weight   int // higher weight = search first
+	Pages    int // 0 uses FETCHER_MAX_PAGE_NUM
+}
+
+// DefaultJoobleQueryPlan is used when no plan file is configured or it can't
+// be read: the long-standing remote, India, US and Europe searches
+func DefaultJoobleQueryPlan() JoobleQueryPlan {
+	return JoobleQueryPlan{
+		Locations: []string{"remote"},
+		Queries: []JoobleQuerySpec{
+			{Keyword: "*", Weight: 10}, // Most general, highest priority
+			{Keyword: "engineer", Weight: 9},
+			{Keyword: "developer", Weight: 9},
+			{Keyword: "software", Weight: 8},
+			{Keyword: "*", Weight: 7, Locations: []string{"india"}},
+			{Keyword: "engineer", Weight: 6, Locations: []string{"india"}},
+			{Keyword: "developer", Weight: 6, Locations: []string{"india"}},
+			{Keyword: "*", Weight: 5, Locations: []string{"united states"}},
+			{Keyword: "*", Weight: 4, Locations: []string{"europe"}},
+		},
+	}
+}
+
+// LoadJoobleQueryPlan reads a query plan from a YAML file
+func LoadJoobleQueryPlan(path string) (JoobleQueryPlan, error) {
+	var plan JoobleQueryPlan
+
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return plan, err
+	}
+	if err := yaml.Unmarshal(buf, &plan); err != nil {
+		return plan, fmt.Errorf("failed to parse Jooble query plan %s: %w", path, err)
+	}
+	for i, q := range plan.Queries {
+		if strings.TrimSpace(q.Keyword) == "" {
+			return plan, fmt.Errorf("jooble query #%d in %s: keyword is required", i+1, path)
+		}
+	}
+	return plan, nil
+}
+
+// Expand turns the plan into one query per keyword/location pair, highest weight
+// first. Pairs repeated across specs keep the highest weight and page budget.
+func (p JoobleQueryPlan) Expand(skills []string) []JoobleQuery {
+	specs := append([]JoobleQuerySpec(nil), p.Queries...)
+	if p.FromSkills != nil {
+		for _, skill := range skills {
+			spec := *p.FromSkills
+			spec.Keyword = skill
+			specs = append(specs, spec)
+		}
+	}
+
+	index := make(map[string]int)
+	var queries []JoobleQuery
+	for _, spec := range specs {
+		locations := spec.Locations
+		if len(locations) == 0 {
+			locations = p.Locations
+		}
+		if len(locations) == 0 {
+			locations = []string{""}
+		}
+
+		for _, location := range locations {
+			key := strings.ToLower(spec.Keyword) + "|" + strings.ToLower(location)
+			if i, ok := index[key]; ok {
+				if spec.Weight > queries[i].Weight {
+					queries[i].Weight = spec.Weight
+				}
+				if spec.Pages > queries[i].Pages {
+					queries[i].Pages = spec.Pages
+				}
+				continue
+			}
+			index[key] = len(queries)
+			queries = append(queries, JoobleQuery{
+				Keyword:  spec.Keyword,
+				Location: location,
+				Weight:   spec.Weight,
+				Pages:    spec.Pages,
+			})
+		}
+	}
+
+	// Sort by weight (highest first), keeping plan order for ties
+	sort.SliceStable(queries, func(i, j int) bool {
+		return queries[i].Weight > queries[j].Weight
+	})
+	return queries
+}
@@ -0,0 +1,44 @@
+package fetch
+
+import "testing"
+
+func TestJoobleQueryPlanExpand(t *testing.T) {
+	plan := JoobleQueryPlan{
+		Locations: []string{"remote"},
+		Queries: []JoobleQuerySpec{
+			{Keyword: "*", Weight: 10},
+			{Keyword: "engineer", Weight: 5, Locations: []string{"india", "remote"}},
+		},
+		FromSkills: &JoobleQuerySpec{Weight: 5, Pages: 1},
+	}
+
+	// The "Engineer" skill repeats engineer/remote, which keeps the larger page budget
+	got := plan.Expand([]string{"Engineer", "go"})
+	want := []JoobleQuery{
+		{Keyword: "*", Location: "remote", Weight: 10},
+		{Keyword: "engineer", Location: "india", Weight: 5},
+		{Keyword: "engineer", Location: "remote", Weight: 5, Pages: 1},
+		{Keyword: "go", Location: "remote", Weight: 5, Pages: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expand = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("query %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDefaultJoobleQueryPlan(t *testing.T) {
+	queries := DefaultJoobleQueryPlan().Expand([]string{"rust"})
+	if len(queries) != 9 {
+		t.Fatalf("default plan has %d queries, want 9", len(queries))
+	}
+	if first := queries[0]; first.Keyword != "*" || first.Location != "remote" || first.Weight != 10 {
+		t.Errorf("first query = %+v, want */remote weight 10", first)
+	}
+	if last := queries[8]; last.Keyword != "*" || last.Location != "europe" || last.Weight != 4 {
+		t.Errorf("last query = %+v, want */europe weight 4", last)
+	}
+}
@@ -0,0 +1,66 @@
+package fetch
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestJoobleReplay(t *testing.T) {
+	useReplay(t)
+
+	src := NewJoobleSource(testJoobleKey, 1, time.Minute, "testdata/jooble_queries.yml", nil)
+	jobs, err := src.Fetch(context.Background(), Options{MaxPages: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2", len(jobs))
+	}
+
+	first := jobs[0]
+	if first.ID != "jooble-9007199254740993" {
+		t.Errorf("ID = %q, want the id's exact digits", first.ID)
+	}
+	if first.Title != "Senior Go Engineer" || first.Company != "Acme Inc" || first.Location != "Remote" {
+		t.Errorf("got %q at %q in %q", first.Title, first.Company, first.Location)
+	}
+	if first.SalaryMin != 120000 || first.SalaryMax != 150000 || first.SalaryCurrency != "USD" {
+		t.Errorf("salary = %d-%d %s, want 120000-150000 USD", first.SalaryMin, first.SalaryMax, first.SalaryCurrency)
+	}
+	if want := time.Date(2026, 10, 10, 8, 30, 0, 0, time.UTC); !first.PublishedAt.Equal(want) {
+		t.Errorf("PublishedAt = %v, want %v", first.PublishedAt, want)
+	}
+
+	second := jobs[1]
+	if second.ID != "jooble--4417125062398171234" {
+		t.Errorf("ID = %q", second.ID)
+	}
+	if second.SalaryMin != 0 || second.SalaryMax != 0 {
+		t.Errorf("salary = %d-%d, want none", second.SalaryMin, second.SalaryMax)
+	}
+}
+
+// fixedCursor is a CursorStore holding one cursor for every key
+type fixedCursor time.Time
+
+func (c fixedCursor) GetSourceCursor(context.Context, string) (time.Time, error) {
+	return time.Time(c), nil
+}
+
+func (c fixedCursor) SaveSourceCursor(context.Context, string, time.Time) error { return nil }
+
+func TestJoobleReplayCursor(t *testing.T) {
+	useReplay(t)
+
+	// Between the two postings' dates, so only the newer one is kept
+	since := fixedCursor(time.Date(2026, 10, 9, 12, 0, 0, 0, time.UTC))
+	src := NewJoobleSource(testJoobleKey, 1, time.Minute, "testdata/jooble_queries.yml", nil)
+	jobs, err := src.Fetch(context.Background(), Options{MaxPages: 3, Cursors: NewCursors(since)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].ID != "jooble-9007199254740993" {
+		t.Errorf("got %d jobs (%v), want only the one newer than the cursor", len(jobs), jobs)
+	}
+}
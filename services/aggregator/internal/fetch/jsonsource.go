@@ -0,0 +1,338 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/salary"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/storage"
+	"gopkg.in/yaml.v3"
+)
+
+// Fields a JSON source can map via JSONSourceConfig.Fields
+var jsonSourceFields = map[string]bool{
+	"id": true, "title": true, "company": true, "company_logo": true, "company_url": true,
+	"description": true, "location": true,
+	"work_type": true, "salary": true, "salary_min": true, "salary_max": true,
+	"salary_currency": true, "salary_period": true,
+	"published_at": true, "url": true, "tags": true,
+}
+
+// JSONSourceConfig declares a JSON list endpoint handled by the generic JSON fetcher
+type JSONSourceConfig struct {
+	// Name is the registry name used in ?sources=
+	Name string `yaml:"name"`
+	// Source is stored in jobs.source, defaults to Name
+	Source string `yaml:"source"`
+	URL    string `yaml:"url"`
+	// Query holds static query parameters added to every request
+	Query map[string]string `yaml:"query"`
+	// IDPrefix is prepended to the mapped id to build jobs.id, defaults to Name
+	IDPrefix string `yaml:"id_prefix"`
+	// JobsPath is the dot path to the job array, empty when the body is the array
+	JobsPath   string          `yaml:"jobs_path"`
+	Pagination *JSONPagination `yaml:"pagination"`
+	// Fields maps a job column to a dot path inside each job ("company.name", "tags.0")
+	Fields map[string]string `yaml:"fields"`
+	// Workplace is the workplace type of every job on the endpoint ("remote"
+	// for remote-only boards), used when a job's location doesn't say
+	Workplace string `yaml:"workplace"`
+}
+
+// JSONPagination describes page- or offset-based pagination of a JSON source
+type JSONPagination struct {
+	// Type is "page" (param counts pages) or "offset" (param counts items)
+	Type  string `yaml:"type"`
+	Param string `yaml:"param"`
+	// Start is the first page number or offset, defaults to 1 for pages and 0 for offsets
+	Start *int `yaml:"start"`
+	// PageSize is the expected number of jobs per page, a short page ends pagination
+	PageSize int `yaml:"page_size"`
+	// SizeParam optionally sends PageSize to the API
+	SizeParam string `yaml:"size_param"`
+}
+
+type jsonSourceFile struct {
+	Sources []JSONSourceConfig `yaml:"json_sources"`
+}
+
+// LoadJSONSourceConfigs reads JSON source definitions from a YAML file with a top-level "json_sources" list
+func LoadJSONSourceConfigs(path string) ([]JSONSourceConfig, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var jf jsonSourceFile
+	if err := yaml.Unmarshal(buf, &jf); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON sources file %s: %w", path, err)
+	}
+
+	for i, sc := range jf.Sources {
+		if sc.Name == "" || sc.URL == "" {
+			return nil, fmt.Errorf("JSON source #%d in %s: name and url are required", i+1, path)
+		}
+		if sc.Fields["id"] == "" || sc.Fields["title"] == "" {
+			return nil, fmt.Errorf("JSON source %s: id and title field paths are required", sc.Name)
+		}
+		for column := range sc.Fields {
+			if !jsonSourceFields[column] {
+				return nil, fmt.Errorf("JSON source %s: unknown field column %q", sc.Name, column)
+			}
+		}
+		if p := sc.Pagination; p != nil {
+			if p.Param == "" || p.PageSize <= 0 {
+				return nil, fmt.Errorf("JSON source %s: pagination needs param and page_size", sc.Name)
+			}
+			if p.Type != "" && p.Type != "page" && p.Type != "offset" {
+				return nil, fmt.Errorf("JSON source %s: unknown pagination type %q", sc.Name, p.Type)
+			}
+		}
+	}
+	return jf.Sources, nil
+}
+
+// JSONSource pulls jobs from any JSON list endpoint described by a JSONSourceConfig
+type JSONSource struct {
+	cfg JSONSourceConfig
+}
+
+// NewJSONSource creates a generic JSON source
+func NewJSONSource(cfg JSONSourceConfig) *JSONSource {
+	if cfg.Source == "" {
+		cfg.Source = cfg.Name
+	}
+	if cfg.IDPrefix == "" {
+		cfg.IDPrefix = cfg.Name
+	}
+	return &JSONSource{cfg: cfg}
+}
+
+func (s *JSONSource) Name() string { return s.cfg.Name }
+
+func (s *JSONSource) Enabled() bool { return s.cfg.URL != "" }
+
+func (s *JSONSource) Fetch(ctx context.Context, opts Options) ([]storage.JobRow, error) {
+	p := s.cfg.Pagination
+	if p == nil {
+		jobs, err := JSONList(ctx, s.cfg, nil)
+		if err != nil {
+			return nil, err
+		}
+		return limitJobs(jobs, opts.JobCount), nil
+	}
+
+	return Paginate(ctx, s.Name(), opts, p.PageSize, func(ctx context.Context, page int) ([]storage.JobRow, error) {
+		params := url.Values{}
+		params.Set(p.Param, strconv.Itoa(paginationValue(p, page)))
+		if p.SizeParam != "" {
+			params.Set(p.SizeParam, strconv.Itoa(p.PageSize))
+		}
+		return JSONList(ctx, s.cfg, params)
+	})
+}
+
+// paginationValue converts a 1-based page number into the configured page or offset parameter
+func paginationValue(p *JSONPagination, page int) int {
+	if p.Type == "offset" {
+		start := 0
+		if p.Start != nil {
+			start = *p.Start
+		}
+		return start + (page-1)*p.PageSize
+	}
+	start := 1
+	if p.Start != nil {
+		start = *p.Start
+	}
+	return start + page - 1
+}
+
+// JSONList fetches a single response of a JSON source and maps each job via the configured field paths
+func JSONList(ctx context.Context, cfg JSONSourceConfig, extra url.Values) ([]storage.JobRow, error) {
+	endpt, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid url: %w", cfg.Name, err)
+	}
+	q := endpt.Query()
+	for k, v := range cfg.Query {
+		q.Set(k, v)
+	}
+	for k, v := range extra {
+		q[k] = v
+	}
+	endpt.RawQuery = q.Encode()
+
+	var raw json.RawMessage
+	if err := getJSON(ctx, endpt.String(), &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.Name, err)
+	}
+	// Numbers stay json.Number so large ids and timestamps keep every digit
+	var body interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&body); err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.Name, &DecodeError{Err: err})
+	}
+
+	list, ok := lookupPath(body, cfg.JobsPath).([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: no job array at path %q", cfg.Name, cfg.JobsPath)
+	}
+
+	jobs := make([]storage.JobRow, 0, len(list))
+	for _, item := range list {
+		field := func(column string) string {
+			if path := cfg.Fields[column]; path != "" {
+				return stringify(lookupPath(item, path))
+			}
+			return ""
+		}
+
+		id := field("id")
+		if id == "" {
+			continue
+		}
+
+		// Numeric and currency/period fields override what the salary text says
+		salaryRaw := strings.Join(strings.Fields(strings.Join([]string{
+			field("salary"), field("salary_min"), field("salary_max"), field("salary_currency"), field("salary_period"),
+		}, " ")), " ")
+		pay := salary.Parse(field("salary"))
+		if v, err := strconv.ParseFloat(field("salary_min"), 64); err == nil {
+			pay.Min = v
+		}
+		if v, err := strconv.ParseFloat(field("salary_max"), 64); err == nil {
+			pay.Max = v
+		}
+		if currency := field("salary_currency"); currency != "" {
+			pay.Currency = strings.ToUpper(currency)
+		}
+		if period := salary.ParsePeriod(field("salary_period")); period != "" {
+			pay.Period = period
+		} else if pay.Period == "" {
+			pay.Period = salary.Yearly
+		}
+
+		var sourceTags []string
+		if path := cfg.Fields["tags"]; path != "" {
+			sourceTags = stringList(lookupPath(item, path))
+		}
+		publishedRaw := jsonTimestamp(cfg.Fields["published_at"], item)
+
+		jobs = append(jobs, withSalary(storage.JobRow{
+			ID:            cfg.IDPrefix + "-" + id,
+			Source:        cfg.Source,
+			Title:         field("title"),
+			Company:       field("company"),
+			CompanyLogo:   field("company_logo"),
+			CompanyURL:    field("company_url"),
+			Description:   field("description"),
+			Location:      field("location"),
+			WorkplaceType: cfg.Workplace,
+			WorkType:      field("work_type"),
+			SourceTags:    sourceTags,
+			URL:           field("url"),
+			PublishedAt:   parsePublished(cfg.Source, publishedRaw),
+			PublishedRaw:  publishedRaw,
+			SalaryRaw:     salaryRaw,
+		}, pay))
+	}
+	return jobs, nil
+}
+
+// lookupPath walks a decoded JSON value along a dot path; numeric segments index arrays
+func lookupPath(v interface{}, path string) interface{} {
+	if path == "" {
+		return v
+	}
+	for _, key := range strings.Split(path, ".") {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			v = node[key]
+		case []interface{}:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return nil
+			}
+			v = node[idx]
+		default:
+			return nil
+		}
+	}
+	return v
+}
+
+// stringify renders a JSON scalar as text; arrays of scalars are joined with ", "
+func stringify(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(val)
+	case json.Number:
+		return val.String()
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	case []interface{}:
+		parts := make([]string, 0, len(val))
+		for _, elem := range val {
+			if s := stringify(elem); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ", ")
+	default:
+		return ""
+	}
+}
+
+// stringList reads an array of scalars, or a comma-separated string, as a list
+func stringList(v interface{}) []string {
+	var list []string
+	switch val := v.(type) {
+	case []interface{}:
+		for _, elem := range val {
+			if s := stringify(elem); s != "" {
+				list = append(list, s)
+			}
+		}
+	case string:
+		for _, s := range strings.Split(val, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				list = append(list, s)
+			}
+		}
+	}
+	return list
+}
+
+// jsonTimestamp reads the published date as text; unix seconds/millis are
+// passed on as whole digits, which dates.Parse recognizes
+func jsonTimestamp(path string, item interface{}) string {
+	if path == "" {
+		return ""
+	}
+	switch val := lookupPath(item, path).(type) {
+	case json.Number:
+		if n, err := val.Int64(); err == nil {
+			return strconv.FormatInt(n, 10)
+		}
+		if f, err := val.Float64(); err == nil {
+			return strconv.FormatInt(int64(f), 10)
+		}
+		return val.String()
+	case float64:
+		return strconv.FormatInt(int64(val), 10)
+	default:
+		return stringify(val)
+	}
+}
@@ -0,0 +1,85 @@
+package fetch
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func decodeNumbers(t *testing.T, s string) interface{} {
+	t.Helper()
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader([]byte(s)))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestLookupPath(t *testing.T) {
+	body := decodeNumbers(t, `{
+		"data": {"jobs": [
+			{"id": 9007199254740993, "title": "Go Engineer", "tags": ["go", "k8s"], "company": {"name": "Acme"}},
+			{"id": "abc", "title": null}
+		]},
+		"meta": {"page": 1}
+	}`)
+
+	tests := []struct {
+		path string
+		want interface{}
+	}{
+		{"", body},
+		{"meta.page", json.Number("1")},
+		{"data.jobs.0.id", json.Number("9007199254740993")},
+		{"data.jobs.0.company.name", "Acme"},
+		{"data.jobs.0.tags.1", "k8s"},
+		{"data.jobs.1.id", "abc"},
+		{"data.jobs.1.title", nil},
+		{"data.jobs.2.id", nil},  // index out of range
+		{"data.jobs.-1.id", nil}, // negative index
+		{"data.jobs.x", nil},     // non-numeric index into an array
+		{"meta.page.value", nil}, // walking into a scalar
+		{"missing.path", nil},
+	}
+	for _, tt := range tests {
+		if got := lookupPath(body, tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("lookupPath(%q) = %#v, want %#v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestStringifyAndTimestamp(t *testing.T) {
+	item := decodeNumbers(t, `{
+		"id": 9007199254740993, "salary": 120000.5, "remote": true, "tags": ["go", 1, null, ""],
+		"secs": 1760000000, "millis": 1760000000123, "float_secs": 1.76e9, "iso": "2026-10-09T08:53:20Z"
+	}`)
+
+	for path, want := range map[string]string{
+		"id":      "9007199254740993",
+		"salary":  "120000.5",
+		"remote":  "true",
+		"tags":    "go, 1",
+		"missing": "",
+	} {
+		if got := stringify(lookupPath(item, path)); got != want {
+			t.Errorf("stringify(%s) = %q, want %q", path, got, want)
+		}
+	}
+
+	for path, want := range map[string]string{
+		"secs":       "1760000000",
+		"millis":     "1760000000123",
+		"float_secs": "1760000000",
+		"iso":        "2026-10-09T08:53:20Z",
+	} {
+		if got := jsonTimestamp(path, item); got != want {
+			t.Errorf("jsonTimestamp(%s) = %q, want %q", path, got, want)
+		}
+	}
+	if got := jsonTimestamp("", item); got != "" {
+		t.Errorf("jsonTimestamp without a path = %q", got)
+	}
+}
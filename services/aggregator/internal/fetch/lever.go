@@ -0,0 +1,128 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/dates"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/salary"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/storage"
+)
+
+type LeverPosting struct {
+	ID         string `json:"id"`
+	Text       string `json:"text"` // job title
+	Categories struct {
+		Commitment string `json:"commitment"` // "Full-time", "Contract", ...
+		Location   string `json:"location"`
+		Team       string `json:"team"`
+	} `json:"categories"`
+	Description string `json:"description"` // HTML
+	Lists       []struct {
+		Text    string `json:"text"`    // e.g. "Requirements"
+		Content string `json:"content"` // HTML <li> items
+	} `json:"lists"`
+	Additional    string `json:"additional"` // HTML
+	HostedURL     string `json:"hostedUrl"`
+	ApplyURL      string `json:"applyUrl"`
+	CreatedAt     int64  `json:"createdAt"`     // epoch millis
+	WorkplaceType string `json:"workplaceType"` // "remote", "hybrid", "on-site", "unspecified"
+	SalaryRange   *struct {
+		Min      float64 `json:"min"`
+		Max      float64 `json:"max"`
+		Currency string  `json:"currency"`
+		Interval string  `json:"interval"` // "per-year-salary", "per-hour-wage", ...
+	} `json:"salaryRange"`
+}
+
+// LeverSource pulls postings for configured companies from the Lever postings API
+type LeverSource struct {
+	baseURL   string
+	companies []string
+}
+
+// NewLeverSource creates a Lever source for the given company slugs,
+// empty baseURL uses the public (US) postings API
+func NewLeverSource(baseURL string, companies []string) *LeverSource {
+	return &LeverSource{baseURL: baseURL, companies: companies}
+}
+
+func (s *LeverSource) Name() string { return "lever" }
+
+// Enabled returns true if at least one company slug is configured
+func (s *LeverSource) Enabled() bool { return len(s.companies) > 0 }
+
+func (s *LeverSource) Fetch(ctx context.Context, opts Options) ([]storage.JobRow, error) {
+	return FetchCompanies(ctx, s.Name(), s.companies, opts, func(ctx context.Context, company string) ([]storage.JobRow, error) {
+		return Lever(ctx, s.baseURL, company)
+	})
+}
+
+// Lever fetches every published posting for a single company. The postings
+// don't carry the company name, so company is the slug optionally followed by
+// the name to show ("netflix=Netflix").
+func Lever(ctx context.Context, baseURL, company string) ([]storage.JobRow, error) {
+	if baseURL == "" {
+		baseURL = "https://api.lever.co"
+	}
+	company, name := companyBoard(company)
+	endpt := fmt.Sprintf("%s/v0/postings/%s?mode=json",
+		strings.TrimRight(baseURL, "/"), url.PathEscape(company))
+
+	var postings []LeverPosting
+	if err := getJSON(ctx, endpt, &postings); err != nil {
+		return nil, fmt.Errorf("lever %s: %w", company, err)
+	}
+
+	out := make([]storage.JobRow, 0, len(postings))
+	for _, p := range postings {
+
+		// Prefer the hosted application form, fall back to the posting page
+		applyURL := p.ApplyURL
+		if applyURL == "" {
+			applyURL = p.HostedURL
+		}
+
+		var publishedAt time.Time
+		if p.CreatedAt > 0 {
+			publishedAt = dates.Unix(p.CreatedAt)
+		}
+
+		var pay salary.Salary
+		if r := p.SalaryRange; r != nil {
+			// "one-time" and unknown intervals can't be annualized
+			if period := salary.ParsePeriod(r.Interval); period != "" {
+				pay = salary.Salary{Min: r.Min, Max: r.Max, Currency: strings.ToUpper(r.Currency), Period: period}
+			}
+		}
+
+		out = append(out, withSalary(storage.JobRow{
+			ID:            fmt.Sprintf("lever-%s-%s", strings.ToLower(company), p.ID),
+			Source:        "lever",
+			Title:         p.Text,
+			Company:       name,
+			Description:   leverDescriptionHTML(p),
+			Location:      p.Categories.Location,
+			WorkplaceType: p.WorkplaceType,
+			WorkType:      p.Categories.Commitment,
+			URL:           applyURL,
+			PublishedAt:   publishedAt,
+		}, pay))
+	}
+	return out, nil
+}
+
+// leverDescriptionHTML stitches the description, titled lists and closing
+// section back into a single HTML document
+func leverDescriptionHTML(p LeverPosting) string {
+	var b strings.Builder
+	b.WriteString(p.Description)
+	for _, list := range p.Lists {
+		b.WriteString("<h3>" + list.Text + "</h3><ul>" + list.Content + "</ul>")
+	}
+	b.WriteString(p.Additional)
+	return b.String()
+}
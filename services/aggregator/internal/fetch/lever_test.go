@@ -0,0 +1,80 @@
+package fetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLever(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v0/postings/acme" || r.URL.Query().Get("mode") != "json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`[
+			{"id": "a1b2", "text": "Senior Go Engineer",
+			 "categories": {"commitment": "Full-time", "location": "London", "team": "Platform"},
+			 "description": "<p>Join us</p>",
+			 "lists": [{"text": "Requirements", "content": "<li>Go</li>"}],
+			 "additional": "<p>Equal opportunity</p>",
+			 "hostedUrl": "https://jobs.lever.co/acme/a1b2", "applyUrl": "https://jobs.lever.co/acme/a1b2/apply",
+			 "createdAt": 1791100800000, "workplaceType": "hybrid",
+			 "salaryRange": {"min": 90000, "max": 110000, "currency": "gbp", "interval": "per-year-salary"}},
+			{"id": "c3d4", "text": "Support Engineer",
+			 "categories": {"commitment": "Contract", "location": ""},
+			 "hostedUrl": "https://jobs.lever.co/acme/c3d4", "workplaceType": "remote",
+			 "salaryRange": {"min": 5000, "max": 5000, "currency": "USD", "interval": "one-time"}}
+		]`))
+	}))
+	defer srv.Close()
+
+	jobs, err := Lever(context.Background(), srv.URL, "acme=Acme Inc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2", len(jobs))
+	}
+
+	first := jobs[0]
+	if first.ID != "lever-acme-a1b2" || first.Company != "Acme Inc" {
+		t.Errorf("ID %q, company %q", first.ID, first.Company)
+	}
+	if first.Location != "London" || first.WorkplaceType != "hybrid" {
+		t.Errorf("location %q, workplace %q, want the location untouched", first.Location, first.WorkplaceType)
+	}
+	if first.URL != "https://jobs.lever.co/acme/a1b2/apply" || first.WorkType != "Full-time" {
+		t.Errorf("URL %q, work type %q", first.URL, first.WorkType)
+	}
+	if !strings.Contains(first.Description, "<h3>Requirements</h3><ul><li>Go</li></ul>") {
+		t.Errorf("Description = %q, want the lists included", first.Description)
+	}
+	if first.SalaryMin != 90000 || first.SalaryMax != 110000 || first.SalaryCurrency != "GBP" {
+		t.Errorf("salary = %d-%d %s", first.SalaryMin, first.SalaryMax, first.SalaryCurrency)
+	}
+	if want := time.UnixMilli(1791100800000); !first.PublishedAt.Equal(want) {
+		t.Errorf("PublishedAt = %v, want %v", first.PublishedAt, want)
+	}
+
+	// A one-time payment isn't a salary; no apply form falls back to the posting
+	second := jobs[1]
+	if second.SalaryMin != 0 || second.SalaryMax != 0 {
+		t.Errorf("salary = %d-%d, want none", second.SalaryMin, second.SalaryMax)
+	}
+	if second.URL != "https://jobs.lever.co/acme/c3d4" || second.WorkplaceType != "remote" {
+		t.Errorf("URL %q, workplace %q", second.URL, second.WorkplaceType)
+	}
+
+	// Without a configured name the slug is title-cased
+	jobs, err = Lever(context.Background(), srv.URL, "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jobs[0].Company != "Acme" {
+		t.Errorf("Company = %q, want Acme", jobs[0].Company)
+	}
+}
@@ -0,0 +1,78 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+)
+
+// FullListSource is a Source whose uncapped Fetch returns every posting live
+// on the board, so a job it stops returning has been taken down. ListPrefix is
+// the jobs.id prefix of the postings the list covers, empty when this
+// particular source isn't a full list.
+type FullListSource interface {
+	Source
+	ListPrefix() string
+}
+
+// maxLivenessBody caps how much of a posting page is searched for closed phrases
+const maxLivenessBody = 256 << 10
+
+// Phrases job boards and ATS pages show on postings that stopped taking applications
+var closedPhrases = [][]byte{
+	// Not "not accepting applications": careers pages say that about other
+	// roles or a paused team in their boilerplate
+	[]byte("no longer accepting applications"),
+	[]byte("job is no longer available"),
+	[]byte("position is no longer available"),
+	[]byte("posting is no longer available"),
+	[]byte("job has expired"),
+	[]byte("job is expired"),
+	[]byte("position has been filled"),
+	[]byte("job has been filled"),
+	[]byte("posting has closed"),
+	[]byte("job has been closed"),
+	[]byte("job is closed"),
+}
+
+// Script and style blocks of SPA job pages carry UI strings for every state,
+// closed included, so they aren't searched
+var scriptRegex = regexp.MustCompile(`(?is)<(script|style)\b.*?</(script|style)>`)
+
+// CheckLiveness fetches a posting URL and reports whether it is closed: gone
+// (404/410) or a page saying applications are no longer accepted. Other
+// failures are returned as errors, as they don't tell either way; that
+// includes 401/403, which posting pages send for bot protection rather than
+// because the job is gone.
+func CheckLiveness(ctx context.Context, url string) (closed bool, err error) {
+	resp, err := HTTPClient().Get(ctx, url)
+	if err != nil {
+		var statusErr *StatusError
+		var authErr *AuthError
+		switch {
+		case errors.As(err, &statusErr) &&
+			(statusErr.StatusCode == http.StatusNotFound || statusErr.StatusCode == http.StatusGone):
+			return true, nil
+		case errors.As(err, &authErr):
+			return false, fmt.Errorf("inconclusive, page answered %d: %w", authErr.StatusCode, err)
+		}
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxLivenessBody))
+	if err != nil {
+		return false, &NetworkError{Err: err}
+	}
+	body = bytes.ToLower(scriptRegex.ReplaceAll(body, nil))
+	for _, phrase := range closedPhrases {
+		if bytes.Contains(body, phrase) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,48 @@
+package fetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckLiveness(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/gone":
+			w.WriteHeader(http.StatusGone)
+		case "/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+		case "/closed":
+			w.Write([]byte(`<h1>Go Engineer</h1><p>This job is no longer accepting applications.</p>`))
+		case "/paused-team":
+			w.Write([]byte(`<h1>Go Engineer</h1><footer>Our design team is not accepting applications right now.</footer>`))
+		case "/spa":
+			w.Write([]byte(`<h1>Go Engineer</h1><script>var t = {closed: "This job has expired"}</script>`))
+		default:
+			w.Write([]byte(`<h1>Go Engineer</h1><a>Apply</a>`))
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		path    string
+		closed  bool
+		wantErr bool
+	}{
+		{"/open", false, false},
+		{"/gone", true, false},
+		{"/closed", true, false},
+		{"/paused-team", false, false},
+		{"/spa", false, false},
+		// Bot protection doesn't say the posting is gone
+		{"/forbidden", false, true},
+	}
+	for _, tt := range tests {
+		closed, err := CheckLiveness(context.Background(), srv.URL+tt.path)
+		if closed != tt.closed || (err != nil) != tt.wantErr {
+			t.Errorf("CheckLiveness(%s) = %v, %v, want closed %v, error %v", tt.path, closed, err, tt.closed, tt.wantErr)
+		}
+	}
+}
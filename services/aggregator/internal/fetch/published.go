@@ -0,0 +1,59 @@
+package fetch
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/dates"
+)
+
+// Timezone of the zone-less timestamps each source (jobs.source) sends.
+// Sources not listed are read as UTC too.
+var defaultSourceTimezones = map[string]*time.Location{
+	"remotive": time.UTC, // publication_date, "2025-08-06T08:00:30"
+	"jooble":   time.UTC, // updated, "2025-08-06T00:00:00.0000000"
+	"workable": time.UTC, // published_on, date only
+}
+
+var (
+	sourceTimezonesMu sync.RWMutex
+	sourceTimezones   = defaultSourceTimezones
+)
+
+// SetSourceTimezones overrides the default timezone zone-less timestamps of
+// a source are read in, keyed by jobs.source
+func SetSourceTimezones(zones map[string]*time.Location) {
+	merged := make(map[string]*time.Location, len(defaultSourceTimezones)+len(zones))
+	for source, loc := range defaultSourceTimezones {
+		merged[source] = loc
+	}
+	for source, loc := range zones {
+		merged[strings.ToLower(source)] = loc
+	}
+
+	sourceTimezonesMu.Lock()
+	defer sourceTimezonesMu.Unlock()
+	sourceTimezones = merged
+}
+
+// sourceTimezone returns the timezone of source's zone-less timestamps
+func sourceTimezone(source string) *time.Location {
+	sourceTimezonesMu.RLock()
+	defer sourceTimezonesMu.RUnlock()
+	if loc, ok := sourceTimezones[source]; ok {
+		return loc
+	}
+	return time.UTC
+}
+
+// parsePublished reads a source's publish date, zero when it's missing or in
+// a format dates.Parse doesn't know. Rows keep the raw value in PublishedRaw
+// so the ingest can report what failed.
+func parsePublished(source, raw string) time.Time {
+	t, err := dates.Parse(raw, sourceTimezone(source))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
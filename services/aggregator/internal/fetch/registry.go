@@ -0,0 +1,82 @@
+package fetch
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Registry holds the job sources known to the aggregator, keyed by name
+type Registry struct {
+	sources map[string]Source
+	order   []string
+}
+
+// NewRegistry creates a registry containing the given sources
+func NewRegistry(sources ...Source) (*Registry, error) {
+	r := &Registry{sources: make(map[string]Source)}
+	for _, s := range sources {
+		if err := r.Register(s); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register adds a source to the registry. Names are case-insensitive and must be unique.
+func (r *Registry) Register(s Source) error {
+	name := strings.ToLower(s.Name())
+	if name == "" {
+		return fmt.Errorf("source name is required")
+	}
+	if _, exists := r.sources[name]; exists {
+		return fmt.Errorf("source %q already registered", name)
+	}
+	r.sources[name] = s
+	r.order = append(r.order, name)
+	return nil
+}
+
+// Get returns the source registered under name
+func (r *Registry) Get(name string) (Source, bool) {
+	s, ok := r.sources[strings.ToLower(strings.TrimSpace(name))]
+	return s, ok
+}
+
+// Names returns all registered source names in registration order
+func (r *Registry) Names() []string {
+	return append([]string(nil), r.order...)
+}
+
+// Resolve maps requested names to registered sources, in registration order.
+// An empty request resolves to every registered source. Names that are not
+// registered are returned separately, sorted, so callers can reject them.
+func (r *Registry) Resolve(names []string) (sources []Source, unknown []string) {
+	if len(names) == 0 {
+		for _, name := range r.order {
+			sources = append(sources, r.sources[name])
+		}
+		return sources, nil
+	}
+
+	requested := make(map[string]bool, len(names))
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if key == "" {
+			continue
+		}
+		if _, ok := r.sources[key]; !ok {
+			unknown = append(unknown, key)
+			continue
+		}
+		requested[key] = true
+	}
+
+	for _, name := range r.order {
+		if requested[name] {
+			sources = append(sources, r.sources[name])
+		}
+	}
+	sort.Strings(unknown)
+	return sources, unknown
+}
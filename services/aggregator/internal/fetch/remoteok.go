@@ -1,11 +1,11 @@
 package fetch
 
 import (
-	"encoding/json"
-	"net/http"
+	"context"
+	"fmt"
 
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/salary"
 	"github.com/sanchitb23/remote-job-radar/aggregator/internal/storage"
-	"github.com/sanchitb23/remote-job-radar/aggregator/internal/utils"
 )
 
 type RemoteOKJob struct {
@@ -26,22 +26,46 @@ type RemoteOKJob struct {
 	URL         string   `json:"url"`
 }
 
-func RemoteOK(baseURL string, jobCount int) ([]storage.JobRow, error) {
+// RemoteOKSource pulls the full RemoteOK job list in a single request
+type RemoteOKSource struct {
+	baseURL string
+}
+
+// NewRemoteOKSource creates a RemoteOK source, empty baseURL uses the public API
+func NewRemoteOKSource(baseURL string) *RemoteOKSource {
+	return &RemoteOKSource{baseURL: baseURL}
+}
+
+func (s *RemoteOKSource) Name() string { return "remoteok" }
+
+func (s *RemoteOKSource) Enabled() bool { return true }
+
+// ListPrefix marks RemoteOK as a full list: the API returns every live job
+func (s *RemoteOKSource) ListPrefix() string { return "remoteok-" }
+
+func (s *RemoteOKSource) Fetch(ctx context.Context, opts Options) ([]storage.JobRow, error) {
+	return RemoteOK(ctx, opts.conditional(), s.baseURL, opts.JobCount)
+}
+
+// RemoteOK fetches the RemoteOK job list, returning ErrNotModified when cache
+// says it hasn't changed. cache may be nil.
+func RemoteOK(ctx context.Context, cache *ConditionalCache, baseURL string, jobCount int) ([]storage.JobRow, error) {
 	if baseURL == "" {
 		baseURL = "https://remoteok.com"
 	}
 	url := baseURL + "/api"
 
-	resp, err := http.Get(url)
+	resp, err := cache.Get(ctx, url)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("remoteok: %w", err)
 	}
 	defer resp.Body.Close()
 
 	var data []RemoteOKJob
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, err
+	if err := decodeJSON(resp, &data); err != nil {
+		return nil, fmt.Errorf("remoteok: %w", err)
 	}
+	cache.MarkDecoded(url)
 
 	// RemoteOK's first element is often metadata, skip it if it has ID 0 or empty
 	jobsData := data
@@ -51,28 +75,34 @@ func RemoteOK(baseURL string, jobCount int) ([]storage.JobRow, error) {
 
 	var jobs []storage.JobRow
 	for _, r := range jobsData {
-		// Convert HTML description to plain text using utility function
-		description, _ := utils.PreprocessText(r.Description, 0)
 
-		// Create work type from tags
+		// The first tag doubles as the category hint, all of them are kept as tags
 		workType := ""
 		if len(r.Tags) > 0 {
-			workType = r.Tags[0] // Use first tag as work type
+			workType = r.Tags[0]
+		}
+
+		logo := r.CompanyLogo
+		if logo == "" {
+			logo = r.Logo
 		}
 
-		jobs = append(jobs, storage.JobRow{
-			ID:          "remoteok-" + r.ID,
-			Source:      "remoteok",
-			Title:       r.Position,
-			Company:     r.Company,
-			Description: description,
-			Location:    r.Location,
-			WorkType:    workType,
-			URL:         r.URL,
-			PublishedAt: r.Date,
-			SalaryMin:   r.SalaryMin,
-			SalaryMax:   r.SalaryMax,
-		})
+		// RemoteOK normalizes salaries to annual US dollars
+		jobs = append(jobs, withSalary(storage.JobRow{
+			ID:            "remoteok-" + r.ID,
+			Source:        "remoteok",
+			Title:         r.Position,
+			Company:       r.Company,
+			CompanyLogo:   logo,
+			Description:   r.Description,
+			Location:      r.Location,
+			WorkplaceType: "remote", // RemoteOK only lists remote jobs
+			WorkType:      workType,
+			SourceTags:    r.Tags,
+			URL:           r.URL,
+			PublishedAt:   parsePublished("remoteok", r.Date),
+			PublishedRaw:  r.Date,
+		}, salary.Salary{Min: float64(r.SalaryMin), Max: float64(r.SalaryMax), Currency: "USD", Period: salary.Yearly}))
 
 		// Limit results if jobCount is specified
 		if jobCount > 0 && len(jobs) >= jobCount {
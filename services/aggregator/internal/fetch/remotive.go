@@ -1,73 +1,95 @@
 package fetch
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"net/http"
+	"strings"
 
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/salary"
 	"github.com/sanchitb23/remote-job-radar/aggregator/internal/storage"
 )
 
 type remotiveResp struct {
 	Jobs []struct {
-		ID          int    `json:"id"`
-		Title       string `json:"title"`
-		CompanyName string `json:"company_name"`
-		Description string `json:"description"`
-		Category    string `json:"category"`
-		Salary      string `json:"salary"` // "80k-100k USD"
-		URL         string `json:"url"`
-		PublicDate  string `json:"publication_date"`            // "2025-08-06T08:00:30"
-		Location    string `json:"candidate_required_location"` // "Remote"
+		ID          int      `json:"id"`
+		Title       string   `json:"title"`
+		CompanyName string   `json:"company_name"`
+		CompanyLogo string   `json:"company_logo"`
+		Description string   `json:"description"`
+		Category    string   `json:"category"`
+		Tags        []string `json:"tags"`
+		Salary      string   `json:"salary"` // "80k-100k USD"
+		URL         string   `json:"url"`
+		PublicDate  string   `json:"publication_date"`            // "2025-08-06T08:00:30"
+		Location    string   `json:"candidate_required_location"` // "Remote"
 	} `json:"jobs"`
 }
 
-func Remotive(baseURL string, jobCount int) ([]storage.JobRow, error) {
+// RemotiveSource pulls the Remotive remote-jobs list in a single request
+type RemotiveSource struct {
+	baseURL string
+}
+
+// NewRemotiveSource creates a Remotive source, empty baseURL uses the public API
+func NewRemotiveSource(baseURL string) *RemotiveSource {
+	return &RemotiveSource{baseURL: baseURL}
+}
+
+func (s *RemotiveSource) Name() string { return "remotive" }
+
+func (s *RemotiveSource) Enabled() bool { return true }
+
+// ListPrefix marks Remotive as a full list: the API returns every live job
+func (s *RemotiveSource) ListPrefix() string { return "remotive-" }
+
+func (s *RemotiveSource) Fetch(ctx context.Context, opts Options) ([]storage.JobRow, error) {
+	return Remotive(ctx, opts.conditional(), s.baseURL, opts.JobCount)
+}
+
+// Remotive fetches the Remotive job list, returning ErrNotModified when cache
+// says it hasn't changed. cache may be nil.
+func Remotive(ctx context.Context, cache *ConditionalCache, baseURL string, jobCount int) ([]storage.JobRow, error) {
+	if baseURL == "" {
+		baseURL = "https://remotive.com"
+	}
 	// Build URL with limit parameter if jobCount is specified
-	url := baseURL + "/api/remote-jobs"
+	url := strings.TrimRight(baseURL, "/") + "/api/remote-jobs"
 	if jobCount > 0 {
 		url = fmt.Sprintf("%s?limit=%d", url, jobCount)
 	}
 
-	resp, err := http.Get(url)
+	resp, err := cache.Get(ctx, url)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("remotive: %w", err)
 	}
 	defer resp.Body.Close()
 
 	var data remotiveResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, err
+	if err = decodeJSON(resp, &data); err != nil {
+		return nil, fmt.Errorf("remotive: %w", err)
 	}
+	cache.MarkDecoded(url)
 
 	rows := make([]storage.JobRow, 0, len(data.Jobs))
 	for _, j := range data.Jobs {
 		// Use source ID with prefix to prevent duplicates within source
 		id := fmt.Sprintf("remotive-%d", j.ID)
-		minSal, maxSal := parseSalary(j.Salary)
-		rows = append(rows, storage.JobRow{
-			ID:          id,
-			Source:      "remotive",
-			Title:       j.Title,
-			Company:     j.CompanyName,
-			Description: j.Description,
-			Location:    "", // Remotive doesn't provide location, only category
-			WorkType:    j.Category,
-			SalaryMin:   minSal,
-			SalaryMax:   maxSal,
-			URL:         j.URL,
-			PublishedAt: j.PublicDate,
-		})
-	}
-	return rows, nil
-}
-
-func parseSalary(s string) (minSal, maxSal int) {
-	// crude "80k-100k" -> 80_000,100_000 parser (skips currency)
-	var low, high int
-	_, err := fmt.Sscanf(s, "%dk-%dk", &low, &high)
-	if err != nil {
-		return 0, 0
+		rows = append(rows, withSalary(storage.JobRow{
+			ID:            id,
+			Source:        "remotive",
+			Title:         j.Title,
+			Company:       j.CompanyName,
+			CompanyLogo:   j.CompanyLogo,
+			Description:   j.Description,
+			Location:      j.Location,
+			WorkplaceType: "remote", // Remotive only lists remote jobs
+			WorkType:      j.Category,
+			SourceTags:    j.Tags,
+			URL:           j.URL,
+			PublishedAt:   parsePublished("remotive", j.PublicDate),
+			PublishedRaw:  j.PublicDate,
+			SalaryRaw:     j.Salary,
+		}, salary.Parse(j.Salary)))
 	}
-	return low * 1000, high * 1000
+	return limitJobs(rows, jobCount), nil
 }
@@ -0,0 +1,181 @@
+package fetch
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// HTTP modes for the source client
+const (
+	HTTPModeLive   = "live"
+	HTTPModeRecord = "record"
+	HTTPModeReplay = "replay"
+)
+
+const redacted = "REDACTED"
+
+// fixture is a recorded response, stored as JSON with a readable body
+type fixture struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"` // secrets redacted
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   string      `json:"body"`
+}
+
+// fixtureKeys names fixtures by request: host, method, redacted URL and body.
+// The name depends on nothing else, so a fixture replays the same regardless
+// of request order, concurrency or how many runs the process has done.
+type fixtureKeys struct {
+	redact []string
+}
+
+func newFixtureKeys(redact []string) *fixtureKeys {
+	var secrets []string
+	for _, s := range redact {
+		if s != "" {
+			secrets = append(secrets, s)
+		}
+	}
+	return &fixtureKeys{redact: secrets}
+}
+
+func (k *fixtureKeys) redactString(s string) string {
+	for _, secret := range k.redact {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return s
+}
+
+// name returns the fixture file name for req and its redacted URL. The request
+// body, if any, is read and restored.
+func (k *fixtureKeys) name(req *http.Request) (name, url string, err error) {
+	var body []byte
+	if req.Body != nil {
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return "", "", err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	url = k.redactString(req.URL.String())
+	sum := sha256.Sum256([]byte(req.Method + " " + url + "\n" + k.redactString(string(body))))
+	return fmt.Sprintf("%s-%s.json", req.URL.Hostname(), hex.EncodeToString(sum[:8])), url, nil
+}
+
+// RecordTransport passes requests to an inner transport and writes every
+// response to dir as a fixture ReplayTransport can serve later. Values in
+// redact (API keys) are replaced before URLs are stored or hashed. A repeated
+// request overwrites its fixture, so a retried request keeps the response the
+// retry got.
+type RecordTransport struct {
+	dir   string
+	inner http.RoundTripper
+	keys  *fixtureKeys
+}
+
+// NewRecordTransport creates a recording transport, nil inner uses http.DefaultTransport
+func NewRecordTransport(dir string, inner http.RoundTripper, redact []string) (*RecordTransport, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("record: %w", err)
+	}
+	if inner == nil {
+		inner = http.DefaultTransport
+	}
+	return &RecordTransport{dir: dir, inner: inner, keys: newFixtureKeys(redact)}, nil
+}
+
+func (t *RecordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name, url, err := t.keys.name(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.inner.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false) // keep URLs and HTML descriptions readable
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(fixture{
+		Method: req.Method,
+		URL:    url,
+		Status: resp.StatusCode,
+		Header: resp.Header,
+		Body:   string(body),
+	}); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(t.dir, name), buf.Bytes(), 0o644); err != nil {
+		return nil, fmt.Errorf("record: %w", err)
+	}
+	return resp, nil
+}
+
+// ReplayTransport serves responses recorded by RecordTransport without touching
+// the network. redact must list the same secrets used when recording. A request
+// without a fixture fails instead of being served some other recording.
+type ReplayTransport struct {
+	dir  string
+	keys *fixtureKeys
+}
+
+// NewReplayTransport creates a transport replaying the fixtures in dir
+func NewReplayTransport(dir string, redact []string) (*ReplayTransport, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("replay: %w", err)
+	}
+	return &ReplayTransport{dir: dir, keys: newFixtureKeys(redact)}, nil
+}
+
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name, url, err := t.keys.name(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := os.ReadFile(filepath.Join(t.dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("replay: no fixture %s for %s %s: %w", name, req.Method, url, err)
+	}
+
+	var f fixture
+	if err := json.Unmarshal(buf, &f); err != nil {
+		return nil, fmt.Errorf("replay: %s: %w", name, err)
+	}
+
+	header := f.Header
+	if header == nil {
+		header = make(http.Header)
+	}
+	header.Del("Content-Length")
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.Status, http.StatusText(f.Status)),
+		StatusCode:    f.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(f.Body)),
+		ContentLength: int64(len(f.Body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,120 @@
+package fetch
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testJoobleKey is the API key the fixtures in testdata/replay were recorded with
+const testJoobleKey = "test-jooble-key"
+
+// useReplay serves every source request from testdata/replay for the rest of the test
+func useReplay(t *testing.T) {
+	t.Helper()
+	transport, err := NewReplayTransport("testdata/replay", []string{testJoobleKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := HTTPClient()
+	SetHTTPClient(NewClient(ClientConfig{Transport: transport}))
+	t.Cleanup(func() { SetHTTPClient(prev) })
+}
+
+// stubTransport answers every request with the same body
+type stubTransport struct {
+	body  string
+	calls int
+}
+
+func (s *stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.calls++
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(s.body)),
+		Request:    req,
+	}, nil
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	dir := t.TempDir()
+	stub := &stubTransport{body: `{"jobs":[]}`}
+	record, err := NewRecordTransport(dir, stub, []string{"s3cret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(rt http.RoundTripper, url string) (*http.Response, error) {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rt.RoundTrip(req)
+	}
+	for _, url := range []string{"https://example.com/api/s3cret?page=1", "https://example.com/api/s3cret?page=2"} {
+		resp, err := get(record, url)
+		if err != nil {
+			t.Fatalf("record %s: %v", url, err)
+		}
+		resp.Body.Close()
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("recorded %d fixtures, want 2", len(files))
+	}
+	for _, f := range files {
+		buf, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(buf), "s3cret") {
+			t.Errorf("%s contains the secret", filepath.Base(f))
+		}
+	}
+
+	replay, err := NewReplayTransport(dir, []string{"s3cret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Replaying a request any number of times serves the same fixture
+	for i := 0; i < 3; i++ {
+		resp, err := get(replay, "https://example.com/api/s3cret?page=2")
+		if err != nil {
+			t.Fatalf("replay #%d: %v", i+1, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if string(body) != stub.body {
+			t.Errorf("replay #%d body = %q, want %q", i+1, body, stub.body)
+		}
+	}
+	if stub.calls != 2 {
+		t.Errorf("inner transport called %d times, want 2", stub.calls)
+	}
+
+	// A request that wasn't recorded fails instead of reusing another fixture
+	if _, err := get(replay, "https://example.com/api/s3cret?page=3"); err == nil {
+		t.Error("replay of an unrecorded request succeeded")
+	}
+}
+
+func TestReplayMissingFixture(t *testing.T) {
+	useReplay(t)
+
+	_, err := Feed(context.Background(), nil, FeedConfig{
+		Name: "wwr-programming",
+		URL:  "https://weworkremotely.com/categories/remote-programming-jobs.rss",
+	}, 0)
+	if err == nil || !strings.Contains(err.Error(), "no fixture") {
+		t.Fatalf("err = %v, want a missing fixture error", err)
+	}
+}
@@ -0,0 +1,254 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/logger"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/salary"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/storage"
+	"go.uber.org/zap"
+)
+
+// defaultPageTimeout bounds a single page request of a paginated source
+const defaultPageTimeout = 30 * time.Second
+
+// Source is a job board the aggregator can pull postings from
+type Source interface {
+	// Name is the stable identifier used in the ?sources= query and in logs
+	Name() string
+	// Enabled reports whether the source is configured (credentials, base URL, ...)
+	Enabled() bool
+	// Fetch pulls jobs from the source, honouring ctx cancellation and opts limits
+	Fetch(ctx context.Context, opts Options) ([]storage.JobRow, error)
+}
+
+// Options controls a single fetch run against a source
+type Options struct {
+	// JobCount caps the number of jobs returned, 0 means no limit
+	JobCount int
+	// MaxPages caps the number of pages requested from paginated sources
+	MaxPages int
+	// Cache makes full-list sources send conditional GETs, nil disables
+	Cache *ConditionalCache
+	// Cursors lets paginated sources stop at already stored postings, nil disables
+	Cursors *Cursors
+}
+
+// conditional returns the cache for full-list requests. Runs capped by JobCount
+// see only part of the list, so they neither send nor record validators.
+func (o Options) conditional() *ConditionalCache {
+	if o.JobCount > 0 {
+		return nil
+	}
+	return o.Cache
+}
+
+// cursors returns the cursors for uncapped runs. A capped run may stop before
+// the newest postings of a relevance-ordered source, so it neither reads nor
+// advances them.
+func (o Options) cursors() *Cursors {
+	if o.JobCount > 0 {
+		return nil
+	}
+	return o.Cursors
+}
+
+// PageFunc fetches a single 1-based page of results
+type PageFunc func(ctx context.Context, page int) ([]storage.JobRow, error)
+
+// Paginate walks pages of a paginated source until a short page is returned,
+// MaxPages is reached, JobCount jobs have been collected or a page holds nothing
+// newer than the source's cursor (name is the cursor key). If a later page
+// fails, the jobs collected so far are returned without an error.
+func Paginate(ctx context.Context, name string, opts Options, pageSize int, fetchPage PageFunc) ([]storage.JobRow, error) {
+	cursors := opts.cursors()
+	since := cursors.Since(ctx, name)
+
+	maxPages := opts.MaxPages
+	if maxPages <= 0 {
+		maxPages = 1
+	}
+	if opts.JobCount > 0 {
+		// Ceiling division: pages needed to reach jobCount
+		pagesNeeded := (opts.JobCount + pageSize - 1) / pageSize
+		if pagesNeeded < maxPages {
+			maxPages = pagesNeeded
+		}
+		logger.Info("Calculated pages needed for job count",
+			zap.String("source", name),
+			zap.Int("jobCount", opts.JobCount),
+			zap.Int("pagesNeeded", pagesNeeded),
+			zap.Int("maxPages", maxPages))
+	}
+
+	var all []storage.JobRow
+	for page := 1; page <= maxPages; page++ {
+		select {
+		case <-ctx.Done():
+			logger.Warn("Fetch interrupted by context cancellation",
+				zap.String("source", name),
+				zap.Int("page", page),
+				zap.Int("jobsFetched", len(all)))
+			return all, ctx.Err()
+		default:
+		}
+
+		pageCtx, cancel := context.WithTimeout(ctx, defaultPageTimeout)
+		jobs, err := fetchPage(pageCtx, page)
+		cancel()
+
+		if err != nil {
+			logger.Error("Page fetch error",
+				zap.String("source", name),
+				zap.Int("page", page),
+				zap.String("kind", string(Kind(err))),
+				zap.Error(err))
+			// Continue with what we have if we got some results. The cursor stays
+			// put so the pages we missed are fetched next run.
+			if len(all) > 0 {
+				logger.Info("Continuing with partial results",
+					zap.String("source", name),
+					zap.Int("count", len(all)))
+				return limitJobs(all, opts.JobCount), nil
+			}
+			return nil, err
+		}
+
+		logger.Info("Retrieved page",
+			zap.String("source", name),
+			zap.Int("count", len(jobs)),
+			zap.Int("page", page))
+		all = append(all, jobs...)
+
+		// Break early on the last page or once the job count limit is reached
+		if len(jobs) < pageSize || (opts.JobCount > 0 && len(all) >= opts.JobCount) {
+			break
+		}
+		if !since.IsZero() && !hasNewer(jobs, since) {
+			logger.Info("Reached already fetched postings, stopping",
+				zap.String("source", name),
+				zap.Int("page", page),
+				zap.Time("cursor", since))
+			break
+		}
+	}
+
+	cursors.Advance(name, all)
+	return limitJobs(all, opts.JobCount), nil
+}
+
+// withSalary fills row's salary columns from s, annualized in its own currency
+func withSalary(row storage.JobRow, s salary.Salary) storage.JobRow {
+	if s.IsZero() {
+		return row
+	}
+	row.SalaryMin, row.SalaryMax = s.Annual()
+	row.SalaryCurrency = s.Currency
+	row.SalaryPeriod = string(s.Period)
+	if row.SalaryRaw == "" {
+		// Structured sources: the amounts as given, before annualizing
+		row.SalaryRaw = fmt.Sprintf("%g-%g %s %s", s.Min, s.Max, s.Currency, s.Period)
+	}
+	return row
+}
+
+// limitJobs trims jobs to jobCount when a limit is set
+func limitJobs(jobs []storage.JobRow, jobCount int) []storage.JobRow {
+	if jobCount > 0 && len(jobs) > jobCount {
+		return jobs[:jobCount]
+	}
+	return jobs
+}
+
+// CompanyFunc fetches every posting for a single company on an ATS
+type CompanyFunc func(ctx context.Context, company string) ([]storage.JobRow, error)
+
+// FetchCompanies runs fetchCompany for each configured company of an ATS
+// source. A failing company is logged and skipped so it doesn't hide the others.
+func FetchCompanies(ctx context.Context, name string, companies []string, opts Options, fetchCompany CompanyFunc) ([]storage.JobRow, error) {
+	var all []storage.JobRow
+	for _, company := range companies {
+		if opts.JobCount > 0 && len(all) >= opts.JobCount {
+			break
+		}
+
+		jobs, err := fetchCompany(ctx, company)
+		if err != nil {
+			if ctx.Err() != nil {
+				return limitJobs(all, opts.JobCount), ctx.Err()
+			}
+			logger.Error("Company fetch error",
+				zap.String("source", name),
+				zap.String("company", company),
+				zap.String("kind", string(Kind(err))),
+				zap.Error(err))
+			continue
+		}
+		logger.Info("Retrieved jobs for company",
+			zap.String("source", name),
+			zap.String("company", company),
+			zap.Int("count", len(jobs)))
+		all = append(all, jobs...)
+	}
+	return limitJobs(all, opts.JobCount), nil
+}
+
+// workplaceLocation prefixes the location with an ATS's explicit workplace
+// type, so remote/hybrid roles don't have to be guessed from free text
+func workplaceLocation(workplaceType, location string) string {
+	var prefix string
+	switch strings.ReplaceAll(strings.ToLower(workplaceType), "-", "") {
+	case "remote":
+		prefix = "Remote"
+	case "hybrid":
+		prefix = "Hybrid"
+	case "onsite":
+		prefix = "On-site"
+	default:
+		return location
+	}
+
+	if location == "" {
+		return prefix
+	}
+	if strings.Contains(strings.ToLower(location), strings.ToLower(prefix)) {
+		return location
+	}
+	return prefix + " - " + location
+}
+
+// companyBoard splits an ATS company entry, "slug" or "slug=Display Name",
+// into the slug the API is queried by and the company name jobs get. Without
+// a display name the slug is title-cased ("acme-corp" -> "Acme Corp").
+func companyBoard(entry string) (slug, name string) {
+	slug, name, _ = strings.Cut(entry, "=")
+	slug, name = strings.TrimSpace(slug), strings.TrimSpace(name)
+	if name != "" {
+		return slug, name
+	}
+
+	words := strings.FieldsFunc(slug, func(r rune) bool { return r == '-' || r == '_' || r == ' ' })
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return slug, strings.Join(words, " ")
+}
+
+// getJSON issues a GET and decodes a JSON response body into v
+func getJSON(ctx context.Context, endpt string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", endpt, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return decodeJSON(resp, v)
+}
@@ -0,0 +1,68 @@
+package fetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWorkable(t *testing.T) {
+	// Older accounts return no name
+	names := map[string]string{"acme": "Acme Corporation", "globex": ""}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, ok := names[strings.TrimPrefix(r.URL.Path, "/api/v1/widget/accounts/")]
+		if !ok || r.URL.Query().Get("details") != "true" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"name": "` + name + `", "jobs": [
+			{"title": "Data Engineer", "shortcode": "AB12CD", "employment_type": "Full-time",
+			 "telecommuting": true, "url": "https://apply.workable.com/j/AB12CD",
+			 "published_on": "2026-10-08", "country": "Greece", "city": "Athens",
+			 "description": "<p>Pipelines</p>"},
+			{"title": "QA Engineer", "shortcode": "EF34GH", "employment_type": "Contract",
+			 "url": "https://apply.workable.com/j/EF34GH", "application_url": "https://apply.workable.com/j/EF34GH/apply",
+			 "published_on": "2026-10-09", "country": "United States", "state": "Texas", "city": "Austin"}
+		]}`))
+	}))
+	defer srv.Close()
+
+	jobs, err := Workable(context.Background(), srv.URL, "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2", len(jobs))
+	}
+
+	first := jobs[0]
+	if first.ID != "workable-acme-AB12CD" || first.Company != "Acme Corporation" {
+		t.Errorf("ID %q, company %q", first.ID, first.Company)
+	}
+	if first.Location != "Athens, Greece" || first.WorkplaceType != "remote" {
+		t.Errorf("location %q, workplace %q, want telecommuting as the workplace", first.Location, first.WorkplaceType)
+	}
+	if want := time.Date(2026, 10, 8, 0, 0, 0, 0, time.UTC); !first.PublishedAt.Equal(want) {
+		t.Errorf("PublishedAt = %v, want %v", first.PublishedAt, want)
+	}
+
+	second := jobs[1]
+	if second.Location != "Austin, Texas, United States" || second.WorkplaceType != "" {
+		t.Errorf("location %q, workplace %q", second.Location, second.WorkplaceType)
+	}
+	if second.URL != "https://apply.workable.com/j/EF34GH/apply" || second.WorkType != "Contract" {
+		t.Errorf("URL %q, work type %q", second.URL, second.WorkType)
+	}
+
+	// Without a name from the API the configured one is used
+	jobs, err = Workable(context.Background(), srv.URL, "globex=Globex Inc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jobs[0].Company != "Globex Inc" {
+		t.Errorf("Company = %q, want the configured name", jobs[0].Company)
+	}
+}
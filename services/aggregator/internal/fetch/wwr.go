@@ -1,75 +1,30 @@
 package fetch
 
-import (
-	"strings"
-	"time"
+import "strings"
 
-	"github.com/mmcdole/gofeed"
-	"github.com/sanchitb23/remote-job-radar/aggregator/internal/storage"
-	"github.com/sanchitb23/remote-job-radar/aggregator/internal/utils"
-)
-
-func WWR(baseURL string, jobCount int) ([]storage.JobRow, error) {
+// WWRFeed is the feed definition for the We Work Remotely all-jobs RSS feed.
+// Titles follow "Company: Job Title", and location/work type come from the
+// custom region/type elements.
+func WWRFeed(baseURL string) FeedConfig {
 	if baseURL == "" {
 		baseURL = "https://weworkremotely.com"
 	}
-	url := baseURL + "/remote-jobs.rss"
-
-	fp := gofeed.NewParser()
-	feed, err := fp.ParseURL(url)
-	if err != nil {
-		return nil, err
+	return FeedConfig{
+		Name:       "wwr",
+		Source:     "weworkremotely",
+		URL:        strings.TrimRight(baseURL, "/") + "/remote-jobs.rss",
+		IDPrefix:   "wwr",
+		TitleSplit: &TitleSplit{Separator: ":", CompanyFirst: true},
+		Fields: map[string]string{
+			FeedFieldLocation: "region",
+			FeedFieldWorkType: "type",
+		},
+		Workplace: "remote",
+		FullList:  true, // the all-jobs feed covers the wwr category feeds too
 	}
+}
 
-	var jobs []storage.JobRow
-	for _, item := range feed.Items {
-		// Extract company from title (usually format: "Company: Job Title")
-		company := ""
-		title := item.Title
-		if colonIndex := strings.Index(item.Title, ":"); colonIndex > 0 {
-			company = strings.TrimSpace(item.Title[:colonIndex])
-			title = strings.TrimSpace(item.Title[colonIndex+1:])
-		}
-
-		// Extract location from region field
-		location := ""
-		if region := item.Custom["region"]; region != "" {
-			location = region
-		}
-
-		// Extract work type from type field
-		workType := ""
-		if jobType := item.Custom["type"]; jobType != "" {
-			workType = jobType
-		}
-
-		// Convert HTML description to plain text using utility function
-		description, _ := utils.PreprocessText(item.Description, 0)
-
-		// Parse published date
-		publishedAt := ""
-		if item.PublishedParsed != nil {
-			publishedAt = item.PublishedParsed.Format(time.RFC3339)
-		}
-
-		jobs = append(jobs, storage.JobRow{
-			ID:          "wwr-" + item.GUID,
-			Source:      "weworkremotely",
-			Title:       title,
-			Company:     company,
-			Description: description,
-			Location:    location,
-			WorkType:    workType,
-			URL:         item.Link,
-			PublishedAt: publishedAt,
-			SalaryMin:   0, // WWR doesn't provide salary info in RSS
-			SalaryMax:   0,
-		})
-
-		// Limit results if jobCount is specified
-		if jobCount > 0 && len(jobs) >= jobCount {
-			break
-		}
-	}
-	return jobs, nil
+// NewWWRSource creates a We Work Remotely source, empty baseURL uses the public feed
+func NewWWRSource(baseURL string) *FeedSource {
+	return NewFeedSource(WWRFeed(baseURL))
 }
@@ -0,0 +1,57 @@
+package fetch
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWWRReplay(t *testing.T) {
+	useReplay(t)
+
+	src := NewWWRSource("")
+	jobs, err := src.Fetch(context.Background(), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2", len(jobs))
+	}
+
+	first := jobs[0]
+	if want := "wwr-https://weworkremotely.com/remote-jobs/acme-inc-senior-go-engineer"; first.ID != want {
+		t.Errorf("ID = %q, want %q", first.ID, want)
+	}
+	if first.Source != "weworkremotely" || first.WorkplaceType != "remote" {
+		t.Errorf("source %q, workplace %q", first.Source, first.WorkplaceType)
+	}
+	if first.Company != "Acme Inc" || first.Title != "Senior Go Engineer" {
+		t.Errorf("title split into %q / %q", first.Company, first.Title)
+	}
+	if first.Location != "Anywhere in the World" || first.WorkType != "Full-Time" {
+		t.Errorf("location %q, work type %q", first.Location, first.WorkType)
+	}
+	if len(first.SourceTags) != 1 || first.SourceTags[0] != "Back-End Programming" {
+		t.Errorf("SourceTags = %v", first.SourceTags)
+	}
+	if !strings.Contains(first.Description, "<li>Go</li>") {
+		t.Errorf("Description = %q, want the raw HTML", first.Description)
+	}
+	if want := time.Date(2026, 10, 15, 14, 2, 11, 0, time.UTC); !first.PublishedAt.Equal(want) {
+		t.Errorf("PublishedAt = %v, want %v", first.PublishedAt, want)
+	}
+
+	// Only the first separator splits, the rest belongs to the title
+	if second := jobs[1]; second.Company != "Globex" || second.Title != "Site Reliability Engineer: Platform" {
+		t.Errorf("title split into %q / %q", second.Company, second.Title)
+	}
+
+	limited, err := src.Fetch(context.Background(), Options{JobCount: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(limited) != 1 {
+		t.Errorf("JobCount 1 returned %d jobs", len(limited))
+	}
+}
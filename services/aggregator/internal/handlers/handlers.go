@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -58,33 +59,46 @@ func (h *Handlers) HealthDB(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(map[string]bool{"ok": true})
 }
 
-func (h *Handlers) TriggerFetch(w http.ResponseWriter, r *http.Request) {
-	logger.Info("Manual fetch triggered", zap.String("remote_addr", r.RemoteAddr))
-
-	// Accept authentication tokens only via headers for security
+// authorizeHeaders checks the X-Manual-Job-Fetch-Token header, or when
+// allowCron the X-Cron-Secret header too, and writes a 401 if neither is valid.
+// Tokens are only accepted via headers for security.
+func (h *Handlers) authorizeHeaders(w http.ResponseWriter, r *http.Request, allowCron bool) bool {
 	token := r.Header.Get("X-Manual-Job-Fetch-Token")
 	cronSecret := r.Header.Get("X-Cron-Secret")
 
 	validToken := token != "" && token == h.config.ManualJobFetchToken
-	validCronSecret := cronSecret != "" && h.config.CronSecret != "" && cronSecret == h.config.CronSecret
+	validCronSecret := allowCron && cronSecret != "" && h.config.CronSecret != "" && cronSecret == h.config.CronSecret
 
 	if !validToken && !validCronSecret {
-		logger.Warn("Missing or invalid X-Manual-Job-Fetch-Token or X-Cron-Secret header", zap.String("remote_addr", r.RemoteAddr))
+		msg := "Missing or invalid X-Manual-Job-Fetch-Token header"
+		if allowCron {
+			msg = "Missing or invalid X-Manual-Job-Fetch-Token or X-Cron-Secret header"
+		}
+		logger.Warn(msg, zap.String("remote_addr", r.RemoteAddr))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusUnauthorized)
 		_ = json.NewEncoder(w).Encode(map[string]interface{}{
 			"ok":      false,
-			"error":   "Missing or invalid X-Manual-Job-Fetch-Token or X-Cron-Secret header",
+			"error":   msg,
 			"message": "Authorization required",
 		})
-		return
+		return false
 	}
 
 	if validToken {
 		logger.Info("Token validation successful", zap.String("remote_addr", r.RemoteAddr))
-	} else if validCronSecret {
+	} else {
 		logger.Info("Cron secret validation successful", zap.String("remote_addr", r.RemoteAddr))
 	}
+	return true
+}
+
+func (h *Handlers) TriggerFetch(w http.ResponseWriter, r *http.Request) {
+	logger.Info("Manual fetch triggered", zap.String("remote_addr", r.RemoteAddr))
+
+	if !h.authorizeHeaders(w, r, true) {
+		return
+	}
 
 	// Parse sources parameter from query string
 	var sources []string
@@ -98,6 +112,21 @@ func (h *Handlers) TriggerFetch(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Reject sources that are not registered so typos don't silently fetch nothing
+	if _, unknown := h.jobService.Registry().Resolve(sources); len(unknown) > 0 {
+		logger.Warn("Unknown sources requested",
+			zap.Strings("unknown", unknown),
+			zap.String("remote_addr", r.RemoteAddr))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":                false,
+			"error":             fmt.Sprintf("unknown sources: %s", strings.Join(unknown, ", ")),
+			"available_sources": h.jobService.Registry().Names(),
+		})
+		return
+	}
+
 	// Parse job_count parameter from query string
 	var jobCount int
 	if jobCountParam := r.URL.Query().Get("job_count"); jobCountParam != "" {
@@ -198,3 +227,47 @@ func (h *Handlers) TriggerClean(w http.ResponseWriter, r *http.Request) {
 		"message": "clean triggered",
 	})
 }
+
+// MergeCompanies folds ?from= into ?into=, for when two spellings of a company
+// weren't caught by name normalization
+func (h *Handlers) MergeCompanies(w http.ResponseWriter, r *http.Request) {
+	logger.Info("Company merge requested", zap.String("remote_addr", r.RemoteAddr))
+	w.Header().Set("Content-Type", "application/json")
+
+	if !h.authorizeHeaders(w, r, false) {
+		return
+	}
+
+	from := strings.TrimSpace(r.URL.Query().Get("from"))
+	into := strings.TrimSpace(r.URL.Query().Get("into"))
+	if from == "" || into == "" || from == into {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":    false,
+			"error": "from and into must be two different company ids",
+		})
+		return
+	}
+
+	if err := h.store.MergeCompanies(r.Context(), from, into); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, storage.ErrCompanyNotFound) {
+			status = http.StatusNotFound
+		} else {
+			logger.Error("Company merge failed", zap.String("from", from), zap.String("into", into), zap.Error(err))
+		}
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":    false,
+			"error": err.Error(),
+		})
+		return
+	}
+
+	logger.Info("Companies merged", zap.String("from", from), zap.String("into", into))
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"ok":   true,
+		"from": from,
+		"into": into,
+	})
+}
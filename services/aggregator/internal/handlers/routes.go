@@ -21,6 +21,7 @@ func (h *Handlers) SetupRoutes() http.Handler {
 	r.Post("/fetch", h.TriggerFetch)
 	r.Get("/healthz", h.Healthz)
 	r.Delete("/clean", h.TriggerClean)
+	r.Post("/companies/merge", h.MergeCompanies)
 
 	return r
 }
@@ -0,0 +1,182 @@
+package location
+
+// Regions a location can resolve to. Groupings like EMEA or APAC expand to
+// several of them.
+const (
+	RegionNorthAmerica = "north-america"
+	RegionLatinAmerica = "latin-america"
+	RegionEurope       = "europe"
+	RegionMiddleEast   = "middle-east"
+	RegionAfrica       = "africa"
+	RegionAsia         = "asia"
+	RegionOceania      = "oceania"
+)
+
+// country is a gazetteer entry; names are matched case-insensitively as whole words
+type country struct {
+	code   string // ISO 3166-1 alpha-2
+	region string
+	names  []string
+}
+
+var countries = []country{
+	{"US", RegionNorthAmerica, []string{"united states", "united states of america", "u.s.", "u.s.a.", "america"}},
+	{"CA", RegionNorthAmerica, []string{"canada"}},
+	{"MX", RegionLatinAmerica, []string{"mexico", "méxico"}},
+	{"BR", RegionLatinAmerica, []string{"brazil", "brasil"}},
+	{"AR", RegionLatinAmerica, []string{"argentina"}},
+	{"CL", RegionLatinAmerica, []string{"chile"}},
+	{"CO", RegionLatinAmerica, []string{"colombia"}},
+	{"PE", RegionLatinAmerica, []string{"peru", "perú"}},
+	{"UY", RegionLatinAmerica, []string{"uruguay"}},
+	{"CR", RegionLatinAmerica, []string{"costa rica"}},
+	{"GB", RegionEurope, []string{"united kingdom", "great britain", "britain", "england", "scotland", "wales", "northern ireland", "u.k."}},
+	{"IE", RegionEurope, []string{"ireland"}},
+	{"DE", RegionEurope, []string{"germany", "deutschland"}},
+	{"FR", RegionEurope, []string{"france"}},
+	{"ES", RegionEurope, []string{"spain", "españa"}},
+	{"PT", RegionEurope, []string{"portugal"}},
+	{"IT", RegionEurope, []string{"italy", "italia"}},
+	{"NL", RegionEurope, []string{"netherlands", "the netherlands", "holland"}},
+	{"BE", RegionEurope, []string{"belgium"}},
+	{"LU", RegionEurope, []string{"luxembourg"}},
+	{"CH", RegionEurope, []string{"switzerland"}},
+	{"AT", RegionEurope, []string{"austria"}},
+	{"DK", RegionEurope, []string{"denmark"}},
+	{"SE", RegionEurope, []string{"sweden"}},
+	{"NO", RegionEurope, []string{"norway"}},
+	{"FI", RegionEurope, []string{"finland"}},
+	{"IS", RegionEurope, []string{"iceland"}},
+	{"PL", RegionEurope, []string{"poland"}},
+	{"CZ", RegionEurope, []string{"czech republic", "czechia"}},
+	{"SK", RegionEurope, []string{"slovakia"}},
+	{"HU", RegionEurope, []string{"hungary"}},
+	{"RO", RegionEurope, []string{"romania"}},
+	{"BG", RegionEurope, []string{"bulgaria"}},
+	{"GR", RegionEurope, []string{"greece"}},
+	{"HR", RegionEurope, []string{"croatia"}},
+	{"SI", RegionEurope, []string{"slovenia"}},
+	{"RS", RegionEurope, []string{"serbia"}},
+	{"EE", RegionEurope, []string{"estonia"}},
+	{"LV", RegionEurope, []string{"latvia"}},
+	{"LT", RegionEurope, []string{"lithuania"}},
+	{"UA", RegionEurope, []string{"ukraine"}},
+	{"CY", RegionEurope, []string{"cyprus"}},
+	{"MT", RegionEurope, []string{"malta"}},
+	{"TR", RegionMiddleEast, []string{"turkey", "türkiye"}},
+	{"IL", RegionMiddleEast, []string{"israel"}},
+	{"AE", RegionMiddleEast, []string{"united arab emirates"}},
+	{"SA", RegionMiddleEast, []string{"saudi arabia"}},
+	{"EG", RegionAfrica, []string{"egypt"}},
+	{"ZA", RegionAfrica, []string{"south africa"}},
+	{"NG", RegionAfrica, []string{"nigeria"}},
+	{"KE", RegionAfrica, []string{"kenya"}},
+	{"GH", RegionAfrica, []string{"ghana"}},
+	{"MA", RegionAfrica, []string{"morocco"}},
+	{"IN", RegionAsia, []string{"india"}},
+	{"PK", RegionAsia, []string{"pakistan"}},
+	{"BD", RegionAsia, []string{"bangladesh"}},
+	{"LK", RegionAsia, []string{"sri lanka"}},
+	{"SG", RegionAsia, []string{"singapore"}},
+	{"MY", RegionAsia, []string{"malaysia"}},
+	{"ID", RegionAsia, []string{"indonesia"}},
+	{"PH", RegionAsia, []string{"philippines"}},
+	{"TH", RegionAsia, []string{"thailand"}},
+	{"VN", RegionAsia, []string{"vietnam", "viet nam"}},
+	{"CN", RegionAsia, []string{"china"}},
+	{"HK", RegionAsia, []string{"hong kong"}},
+	{"TW", RegionAsia, []string{"taiwan"}},
+	{"JP", RegionAsia, []string{"japan"}},
+	{"KR", RegionAsia, []string{"south korea", "korea"}},
+	{"AU", RegionOceania, []string{"australia"}},
+	{"NZ", RegionOceania, []string{"new zealand"}},
+}
+
+// Abbreviations matched case-sensitively only, so "us" and "in" in prose don't count
+var countryCodes = map[string]string{
+	"US": "US", "USA": "US", "UK": "GB", "UAE": "AE", "NZ": "NZ",
+}
+
+// Cities common in postings, mapped to their country
+var cities = map[string]string{
+	"san francisco": "US", "new york": "US", "new york city": "US", "nyc": "US",
+	"seattle": "US", "austin": "US", "boston": "US", "chicago": "US", "los angeles": "US",
+	"denver": "US", "atlanta": "US", "miami": "US", "washington dc": "US", "san diego": "US",
+	"portland": "US", "palo alto": "US", "mountain view": "US", "san jose": "US",
+	"toronto": "CA", "vancouver": "CA", "montreal": "CA", "ottawa": "CA", "calgary": "CA",
+	"mexico city": "MX", "são paulo": "BR", "sao paulo": "BR", "rio de janeiro": "BR",
+	"buenos aires": "AR", "santiago": "CL", "bogotá": "CO", "bogota": "CO", "medellín": "CO",
+	"medellin": "CO", "lima": "PE", "montevideo": "UY",
+	"london": "GB", "manchester": "GB", "edinburgh": "GB", "bristol": "GB", "cambridge": "GB",
+	"dublin": "IE", "berlin": "DE", "munich": "DE", "hamburg": "DE", "frankfurt": "DE",
+	"cologne": "DE", "paris": "FR", "lyon": "FR", "madrid": "ES", "barcelona": "ES",
+	"valencia": "ES", "lisbon": "PT", "porto": "PT", "milan": "IT", "rome": "IT",
+	"amsterdam": "NL", "rotterdam": "NL", "utrecht": "NL", "brussels": "BE", "zurich": "CH",
+	"zürich": "CH", "geneva": "CH", "vienna": "AT", "copenhagen": "DK", "stockholm": "SE",
+	"oslo": "NO", "helsinki": "FI", "warsaw": "PL", "krakow": "PL", "kraków": "PL",
+	"wroclaw": "PL", "prague": "CZ", "budapest": "HU", "bucharest": "RO", "sofia": "BG",
+	"athens": "GR", "zagreb": "HR", "belgrade": "RS", "tallinn": "EE", "riga": "LV",
+	"vilnius": "LT", "kyiv": "UA", "kiev": "UA", "istanbul": "TR", "tel aviv": "IL",
+	"dubai": "AE", "abu dhabi": "AE", "cairo": "EG", "cape town": "ZA", "johannesburg": "ZA",
+	"lagos": "NG", "nairobi": "KE", "bengaluru": "IN", "bangalore": "IN", "mumbai": "IN",
+	"delhi": "IN", "new delhi": "IN", "hyderabad": "IN", "pune": "IN", "chennai": "IN",
+	"gurgaon": "IN", "gurugram": "IN", "noida": "IN", "kolkata": "IN", "karachi": "PK",
+	"lahore": "PK", "dhaka": "BD", "kuala lumpur": "MY", "jakarta": "ID", "manila": "PH",
+	"bangkok": "TH", "ho chi minh city": "VN", "hanoi": "VN", "shanghai": "CN",
+	"beijing": "CN", "shenzhen": "CN", "taipei": "TW", "tokyo": "JP", "seoul": "KR",
+	"sydney": "AU", "melbourne": "AU", "brisbane": "AU", "perth": "AU", "auckland": "NZ",
+	"wellington": "NZ",
+}
+
+// US states, by name and by the postal code after a city ("Austin, TX")
+var usStates = []string{
+	"alabama", "alaska", "arizona", "arkansas", "california", "colorado", "connecticut",
+	"delaware", "florida", "georgia", "hawaii", "idaho", "illinois", "indiana", "iowa",
+	"kansas", "kentucky", "louisiana", "maine", "maryland", "massachusetts", "michigan",
+	"minnesota", "mississippi", "missouri", "montana", "nebraska", "nevada",
+	"new hampshire", "new jersey", "new mexico", "north carolina", "north dakota", "ohio",
+	"oklahoma", "oregon", "pennsylvania", "rhode island", "south carolina", "south dakota",
+	"tennessee", "texas", "utah", "vermont", "virginia", "washington", "west virginia",
+	"wisconsin", "wyoming",
+}
+
+var usStateCodes = []string{
+	"AL", "AK", "AZ", "AR", "CA", "CO", "CT", "DE", "FL", "GA", "HI", "ID", "IL", "IN",
+	"IA", "KS", "KY", "LA", "ME", "MD", "MA", "MI", "MN", "MS", "MO", "MT", "NE", "NV",
+	"NH", "NJ", "NM", "NY", "NC", "ND", "OH", "OK", "OR", "PA", "RI", "SC", "SD", "TN",
+	"TX", "UT", "VT", "VA", "WA", "WV", "WI", "WY", "DC",
+}
+
+// Region names and groupings
+var regions = map[string][]string{
+	"north america":         {RegionNorthAmerica},
+	"latin america":         {RegionLatinAmerica},
+	"latam":                 {RegionLatinAmerica},
+	"south america":         {RegionLatinAmerica},
+	"central america":       {RegionLatinAmerica},
+	"americas":              {RegionNorthAmerica, RegionLatinAmerica},
+	"europe":                {RegionEurope},
+	"european union":        {RegionEurope},
+	"eu":                    {RegionEurope},
+	"eea":                   {RegionEurope},
+	"emea":                  {RegionEurope, RegionMiddleEast, RegionAfrica},
+	"middle east":           {RegionMiddleEast},
+	"mena":                  {RegionMiddleEast, RegionAfrica},
+	"africa":                {RegionAfrica},
+	"asia":                  {RegionAsia},
+	"apac":                  {RegionAsia, RegionOceania},
+	"asia pacific":          {RegionAsia, RegionOceania},
+	"asia-pacific":          {RegionAsia, RegionOceania},
+	"southeast asia":        {RegionAsia},
+	"oceania":               {RegionOceania},
+	"anz":                   {RegionOceania},
+	"australia/new zealand": {RegionOceania},
+}
+
+// UTC offsets of timezone abbreviations seen in postings, in hours
+var tzOffsets = map[string]float64{
+	"UTC": 0, "GMT": 0, "WET": 0, "BST": 1, "CET": 1, "CEST": 2, "EET": 2, "EEST": 3,
+	"IST": 5.5, "SGT": 8, "JST": 9, "AEST": 10, "AEDT": 11,
+	"ET": -5, "EST": -5, "EDT": -4, "CT": -6, "CST": -6, "CDT": -5,
+	"MT": -7, "MST": -7, "MDT": -6, "PT": -8, "PST": -8, "PDT": -7,
+}
@@ -0,0 +1,322 @@
+package location
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Workplace is where the work happens
+type Workplace string
+
+const (
+	Remote Workplace = "remote"
+	Hybrid Workplace = "hybrid"
+	Onsite Workplace = "onsite"
+)
+
+// Location is the structured form of a posting's free-text location
+type Location struct {
+	Workplace Workplace // empty when the text doesn't say
+	Countries []string  // ISO 3166-1 alpha-2 codes, in order of mention
+	Regions   []string  // Region* constants named ("EMEA", "Europe") or of the countries mentioned
+	// Anywhere is true for postings that say they're open worldwide
+	Anywhere bool
+	// Eligibility holds restrictions as written: "US only", "based in Canada",
+	// "except US", "UTC±3". Excluded places aren't in Countries or Regions.
+	Eligibility []string
+	// UTC offset range in hours candidates must work from, nil when not stated
+	UTCOffsetMin, UTCOffsetMax *float64
+}
+
+// ParseWorkplace maps an ATS or source workplace value ("Remote", "on-site",
+// "OnSite") to a Workplace, empty when it isn't one
+func ParseWorkplace(s string) Workplace {
+	switch strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(s)) {
+	case "remote", "fullyremote":
+		return Remote
+	case "hybrid":
+		return Hybrid
+	case "onsite", "inoffice", "office":
+		return Onsite
+	default:
+		return ""
+	}
+}
+
+var (
+	dottedAbbrevRegex = regexp.MustCompile(`(?i)\bu\.s\.a\.?|\bu\.s\.|\bu\.k\.`)
+	codeRegex         = regexp.MustCompile(`\b(US|USA|UK|UAE|NZ)\b`)
+	stateCodeRegex    = regexp.MustCompile(`,\s*(` + strings.Join(usStateCodes, "|") + `)\b`)
+	placeRegex        *regexp.Regexp
+
+	hybridRegex   = regexp.MustCompile(`(?i)\bhybrid\b`)
+	remoteRegex   = regexp.MustCompile(`(?i)\b(remote|remotely|anywhere|worldwide|work from home|wfh|distributed|telecommute)\b`)
+	onsiteRegex   = regexp.MustCompile(`(?i)\b(on-?site|in[- ]office|office[- ]based|in[- ]person)\b`)
+	anywhereRegex = regexp.MustCompile(`(?i)\b(anywhere|worldwide|world-wide|global(ly)?|international)\b`)
+
+	onlyRegex = regexp.MustCompile(`(?i)^[\s(\-–]*only\b`)
+	// "except US", "outside of the EU", "not in Canada"
+	excludeRegex = regexp.MustCompile(`(?i)\b(except|excluding|excl\.|not|outside|other than)(\s+(for|in|from|of))?\s+(the\s+)?$`)
+	// Between places of one list: "except US, Canada or Mexico"
+	listJoinRegex = regexp.MustCompile(`(?i)^\s*(,|/|&|and|or|nor)?\s*(the\s+)?$`)
+	basedRegex    = regexp.MustCompile(`(?i)\b(must be |candidates |applicants )?(based|located|residing|living|residents?|authori[sz]ed to work|eligible to work) (in|of) (the )?$`)
+
+	tzRegex        = regexp.MustCompile(`\b(UTC|GMT|WET|BST|CET|CEST|EET|EEST|IST|SGT|JST|AEST|AEDT|ET|EST|EDT|CT|CST|CDT|MT|MST|MDT|PT|PST|PDT)(\s*[+\-−]\s*\d{1,2}(?::?[0-5]\d)?)?(\s*(?:±|\+/-|\+-|\+/−)\s*(\d{1,2}(?:\.\d)?)\s*(?:h\b|hrs?\b|hours?\b)?)?`)
+	tzContextRegex = regexp.MustCompile(`(?i)time\s*zones?|\btz\b|overlap`)
+	tzJoinRegex    = regexp.MustCompile(`(?i)^\s*(to|-|–|—|and|through)\s*$`)
+)
+
+// place is a gazetteer hit
+type place struct {
+	countries []string
+	regions   []string
+}
+
+var (
+	placeNames     = make(map[string]place)
+	countryRegions = make(map[string]string) // country code -> region
+)
+
+func init() {
+	add := func(name string, p place) {
+		existing := placeNames[name]
+		existing.countries = append(existing.countries, p.countries...)
+		existing.regions = append(existing.regions, p.regions...)
+		placeNames[name] = existing
+	}
+	for _, c := range countries {
+		countryRegions[c.code] = c.region
+		for _, name := range c.names {
+			add(name, place{countries: []string{c.code}})
+		}
+	}
+	for name, code := range cities {
+		add(name, place{countries: []string{code}})
+	}
+	for _, name := range usStates {
+		add(name, place{countries: []string{"US"}})
+	}
+	for name, rs := range regions {
+		add(name, place{regions: rs})
+	}
+
+	// Longest first, so "latin america" wins over "america"
+	names := make([]string, 0, len(placeNames))
+	for name := range placeNames {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) > len(names[j])
+		}
+		return names[i] < names[j]
+	})
+
+	alts := make([]string, len(names))
+	for i, name := range names {
+		alts[i] = wordBoundary(name[0]) + regexp.QuoteMeta(name) + wordBoundary(name[len(name)-1])
+	}
+	placeRegex = regexp.MustCompile(`(?i)(?:` + strings.Join(alts, "|") + `)`)
+}
+
+// wordBoundary returns \b for ASCII letters and digits; \b doesn't work next to
+// accented letters or punctuation
+func wordBoundary(b byte) string {
+	if b < unicode.MaxASCII && (unicode.IsLetter(rune(b)) || unicode.IsDigit(rune(b))) {
+		return `\b`
+	}
+	return ""
+}
+
+// mention is a place found in the text
+type mention struct {
+	start, end int
+	place
+}
+
+// Parse normalizes a free-text location such as "Remote - US only", "Anywhere",
+// "EMEA", "Bengaluru" or "Remote (UTC-5 to UTC+1)"
+func Parse(text string) Location {
+	text = dottedAbbrevRegex.ReplaceAllStringFunc(text, func(s string) string {
+		return strings.ToUpper(strings.ReplaceAll(s, ".", ""))
+	})
+
+	var loc Location
+	switch {
+	case hybridRegex.MatchString(text):
+		loc.Workplace = Hybrid
+	case remoteRegex.MatchString(text):
+		loc.Workplace = Remote
+	case onsiteRegex.MatchString(text):
+		loc.Workplace = Onsite
+	}
+
+	mentions, excluded := splitExcluded(text, findPlaces(text))
+	for _, m := range mentions {
+		loc.Countries = appendUnique(loc.Countries, m.countries...)
+		loc.Regions = appendUnique(loc.Regions, m.regions...)
+	}
+	// A country is in its region, so region filters find "Germany" too
+	for _, code := range loc.Countries {
+		if region := countryRegions[code]; region != "" {
+			loc.Regions = appendUnique(loc.Regions, region)
+		}
+	}
+	// "Anywhere in the US" is not worldwide, "Worldwide (except US)" still is
+	loc.Anywhere = anywhereRegex.MatchString(text) && len(mentions) == 0
+	loc.Eligibility = appendUnique(loc.Eligibility, excluded...)
+
+	for _, m := range mentions {
+		if only := onlyRegex.FindString(text[m.end:]); only != "" {
+			loc.Eligibility = appendUnique(loc.Eligibility, text[m.start:m.end+len(only)])
+		}
+		if based := basedRegex.FindString(text[:m.start]); based != "" {
+			loc.Eligibility = appendUnique(loc.Eligibility, strings.TrimSpace(text[m.start-len(based):m.end]))
+		}
+	}
+
+	if phrase, lo, hi, ok := parseTimezones(text); ok {
+		loc.UTCOffsetMin, loc.UTCOffsetMax = &lo, &hi
+		loc.Eligibility = appendUnique(loc.Eligibility, phrase...)
+	}
+	return loc
+}
+
+// findPlaces returns every gazetteer hit in text, in order
+func findPlaces(text string) []mention {
+	var mentions []mention
+	for _, idx := range placeRegex.FindAllStringIndex(text, -1) {
+		p := placeNames[strings.ToLower(text[idx[0]:idx[1]])]
+		mentions = append(mentions, mention{idx[0], idx[1], p})
+	}
+	for _, idx := range codeRegex.FindAllStringSubmatchIndex(text, -1) {
+		code := countryCodes[text[idx[2]:idx[3]]]
+		mentions = append(mentions, mention{idx[0], idx[1], place{countries: []string{code}}})
+	}
+	// "Austin, TX"
+	for _, idx := range stateCodeRegex.FindAllStringSubmatchIndex(text, -1) {
+		mentions = append(mentions, mention{idx[2], idx[3], place{countries: []string{"US"}}})
+	}
+	sort.SliceStable(mentions, func(i, j int) bool { return mentions[i].start < mentions[j].start })
+	return mentions
+}
+
+// splitExcluded separates the places text rules out ("except US, Canada")
+// from the ones it names, returning the exclusions as written
+func splitExcluded(text string, all []mention) (included []mention, excluded []string) {
+	start, end := -1, 0 // the exclusion phrase being read
+	for _, m := range all {
+		switch {
+		case m.start < end:
+			// Inside the previous mention ("Austin, TX")
+			if start < 0 {
+				included = append(included, m)
+			}
+			continue
+		case start >= 0 && listJoinRegex.MatchString(text[end:m.start]):
+			end = m.end
+			continue
+		}
+		if start >= 0 {
+			excluded = append(excluded, text[start:end])
+			start = -1
+		}
+		if kw := excludeRegex.FindString(text[:m.start]); kw != "" {
+			start, end = m.start-len(kw), m.end
+			continue
+		}
+		included = append(included, m)
+		end = m.end
+	}
+	if start >= 0 {
+		excluded = append(excluded, text[start:end])
+	}
+	return included, excluded
+}
+
+// parseTimezones finds timezone requirements such as "UTC±3", "CET +/- 2h" or
+// "UTC-5 to UTC+1" and returns them with the overall offset range they allow.
+// A bare abbreviation only counts when the text talks about time zones, so
+// "Hartford, CT" isn't read as Central Time.
+func parseTimezones(text string) (phrases []string, lo, hi float64, ok bool) {
+	matches := tzRegex.FindAllStringSubmatchIndex(text, -1)
+	context := tzContextRegex.MatchString(text)
+
+	for i := 0; i < len(matches); i++ {
+		m := matches[i]
+		start, end := m[0], m[1]
+		base := tzOffsets[text[m[2]:m[3]]]
+		if m[4] >= 0 {
+			base += parseOffset(text[m[4]:m[5]])
+		}
+		spread := 0.0
+		if m[8] >= 0 {
+			spread, _ = strconv.ParseFloat(text[m[8]:m[9]], 64)
+		}
+		mlo, mhi := base-spread, base+spread
+		explicit := m[4] >= 0 || m[6] >= 0
+
+		// "UTC-5 to UTC+1" is one range
+		if i+1 < len(matches) && tzJoinRegex.MatchString(text[end:matches[i+1][0]]) {
+			next := matches[i+1]
+			nextBase := tzOffsets[text[next[2]:next[3]]]
+			if next[4] >= 0 {
+				nextBase += parseOffset(text[next[4]:next[5]])
+			}
+			mlo, mhi = min(mlo, nextBase), max(mhi, nextBase)
+			end = next[1]
+			explicit = true
+			i++
+		}
+
+		if !explicit && !context {
+			continue
+		}
+		if !ok {
+			lo, hi, ok = mlo, mhi, true
+		} else {
+			lo, hi = min(lo, mlo), max(hi, mhi)
+		}
+		phrases = append(phrases, strings.TrimSpace(text[start:end]))
+	}
+	return phrases, lo, hi, ok
+}
+
+// parseOffset reads "+5", "-3:30" or "+0530" as hours
+func parseOffset(s string) float64 {
+	s = strings.Join(strings.Fields(strings.ReplaceAll(s, "−", "-")), "")
+	sign := 1.0
+	if strings.HasPrefix(s, "-") {
+		sign = -1
+	}
+	s = strings.TrimLeft(s, "+-")
+	s = strings.ReplaceAll(s, ":", "")
+
+	var hours, minutes int
+	if len(s) > 2 {
+		hours, _ = strconv.Atoi(s[:len(s)-2])
+		minutes, _ = strconv.Atoi(s[len(s)-2:])
+	} else {
+		hours, _ = strconv.Atoi(s)
+	}
+	return sign * (float64(hours) + float64(minutes)/60)
+}
+
+func appendUnique(list []string, items ...string) []string {
+	for _, item := range items {
+		found := false
+		for _, existing := range list {
+			if existing == item {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, item)
+		}
+	}
+	return list
+}
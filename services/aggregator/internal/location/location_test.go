@@ -0,0 +1,101 @@
+package location
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in          string
+		workplace   Workplace
+		countries   []string
+		regions     []string
+		anywhere    bool
+		eligibility []string
+	}{
+		{in: "Germany", countries: []string{"DE"}, regions: []string{"europe"}},
+		{in: "Bengaluru", countries: []string{"IN"}, regions: []string{"asia"}},
+		{in: "Remote - US only", workplace: Remote, countries: []string{"US"}, regions: []string{"north-america"}, eligibility: []string{"US only"}},
+		{in: "Austin, TX", countries: []string{"US"}, regions: []string{"north-america"}},
+		{in: "Anywhere", workplace: Remote, anywhere: true},
+		{in: "Remote - Anywhere in the U.S.", workplace: Remote, countries: []string{"US"}, regions: []string{"north-america"}},
+		{in: "Hybrid - London, UK", workplace: Hybrid, countries: []string{"GB"}, regions: []string{"europe"}},
+		{in: "On-site", workplace: Onsite},
+		{in: "Remote (must be based in Canada)", workplace: Remote, countries: []string{"CA"}, regions: []string{"north-america"}, eligibility: []string{"must be based in Canada"}},
+		{in: "Germany, France or Spain", countries: []string{"DE", "FR", "ES"}, regions: []string{"europe"}},
+		// Exclusions aren't places the job is open to
+		{in: "Worldwide (except US)", workplace: Remote, anywhere: true, eligibility: []string{"except US"}},
+		{in: "Remote - anywhere except US, Canada or Mexico", workplace: Remote, anywhere: true, eligibility: []string{"except US, Canada or Mexico"}},
+		{in: "Europe, excluding Germany", countries: nil, regions: []string{"europe"}, eligibility: []string{"excluding Germany"}},
+		{in: "Remote, outside of the EU", workplace: Remote, eligibility: []string{"outside of the EU"}},
+		{in: "Canada or US, not Mexico", countries: []string{"CA", "US"}, regions: []string{"north-america"}, eligibility: []string{"not Mexico"}},
+	}
+	for _, tt := range tests {
+		got := Parse(tt.in)
+		if got.Workplace != tt.workplace {
+			t.Errorf("Parse(%q).Workplace = %q, want %q", tt.in, got.Workplace, tt.workplace)
+		}
+		if !reflect.DeepEqual(got.Countries, tt.countries) {
+			t.Errorf("Parse(%q).Countries = %v, want %v", tt.in, got.Countries, tt.countries)
+		}
+		if !reflect.DeepEqual(got.Regions, tt.regions) {
+			t.Errorf("Parse(%q).Regions = %v, want %v", tt.in, got.Regions, tt.regions)
+		}
+		if got.Anywhere != tt.anywhere {
+			t.Errorf("Parse(%q).Anywhere = %v, want %v", tt.in, got.Anywhere, tt.anywhere)
+		}
+		if !reflect.DeepEqual(got.Eligibility, tt.eligibility) {
+			t.Errorf("Parse(%q).Eligibility = %q, want %q", tt.in, got.Eligibility, tt.eligibility)
+		}
+	}
+}
+
+func TestParseRegionsDeduplicated(t *testing.T) {
+	got := Parse("Remote - EMEA, Germany").Regions
+	want := []string{"europe", "middle-east", "africa"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Regions = %v, want %v", got, want)
+	}
+}
+
+func TestParseTimezones(t *testing.T) {
+	tests := []struct {
+		in     string
+		lo, hi float64
+	}{
+		{"Remote (UTC-5 to UTC+1)", -5, 1},
+		{"UTC±3", -3, 3},
+		{"Remote, CET +/- 2h", -1, 3},
+	}
+	for _, tt := range tests {
+		got := Parse(tt.in)
+		if got.UTCOffsetMin == nil || got.UTCOffsetMax == nil {
+			t.Errorf("Parse(%q) found no offset range", tt.in)
+			continue
+		}
+		if *got.UTCOffsetMin != tt.lo || *got.UTCOffsetMax != tt.hi {
+			t.Errorf("Parse(%q) offsets = %v..%v, want %v..%v", tt.in, *got.UTCOffsetMin, *got.UTCOffsetMax, tt.lo, tt.hi)
+		}
+	}
+
+	// A state code is not a timezone unless the text talks about time zones
+	if got := Parse("Hartford, CT"); got.UTCOffsetMin != nil {
+		t.Errorf("Parse(\"Hartford, CT\") read a timezone: %v", *got.UTCOffsetMin)
+	}
+}
+
+func TestParseWorkplace(t *testing.T) {
+	for in, want := range map[string]Workplace{
+		"Remote":    Remote,
+		"on-site":   Onsite,
+		"OnSite":    Onsite,
+		"In Office": Onsite,
+		"HYBRID":    Hybrid,
+		"flexible":  "",
+	} {
+		if got := ParseWorkplace(in); got != want {
+			t.Errorf("ParseWorkplace(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
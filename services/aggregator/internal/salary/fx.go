@@ -0,0 +1,57 @@
+package salary
+
+import (
+	"math"
+	"strings"
+)
+
+// Rates maps an ISO 4217 currency code to its value in US dollars
+type Rates map[string]float64
+
+// DefaultRates are approximate mid-market rates, good enough for filtering.
+// Override or extend them with SALARY_FX_RATES.
+func DefaultRates() Rates {
+	return Rates{
+		"USD": 1,
+		"EUR": 1.08,
+		"GBP": 1.27,
+		"CAD": 0.73,
+		"AUD": 0.66,
+		"NZD": 0.6,
+		"CHF": 1.13,
+		"SEK": 0.095,
+		"NOK": 0.093,
+		"DKK": 0.145,
+		"PLN": 0.25,
+		"CZK": 0.043,
+		"INR": 0.012,
+		"SGD": 0.74,
+		"JPY": 0.0067,
+		"BRL": 0.18,
+		"MXN": 0.055,
+		"ZAR": 0.054,
+		"ILS": 0.27,
+	}
+}
+
+// With returns a copy of r with overrides applied on top
+func (r Rates) With(overrides map[string]float64) Rates {
+	out := make(Rates, len(r)+len(overrides))
+	for code, rate := range r {
+		out[code] = rate
+	}
+	for code, rate := range overrides {
+		out[strings.ToUpper(code)] = rate
+	}
+	return out
+}
+
+// ToUSD converts amount in currency to US dollars, ok is false when the
+// currency is empty or has no rate
+func (r Rates) ToUSD(amount int, currency string) (usd int, ok bool) {
+	rate, ok := r[strings.ToUpper(currency)]
+	if !ok || rate <= 0 {
+		return 0, false
+	}
+	return int(math.Round(float64(amount) * rate)), true
+}
@@ -0,0 +1,269 @@
+package salary
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Period is the pay interval a salary was advertised in
+type Period string
+
+const (
+	Hourly  Period = "hourly"
+	Daily   Period = "daily"
+	Weekly  Period = "weekly"
+	Monthly Period = "monthly"
+	Yearly  Period = "yearly"
+)
+
+// Multipliers from a period to a year, assuming full-time work
+var periodsPerYear = map[Period]float64{
+	Hourly:  2080,
+	Daily:   260,
+	Weekly:  52,
+	Monthly: 12,
+	Yearly:  1,
+}
+
+// Salary is a pay range as advertised. Min or Max is 0 when the posting only
+// gives one bound ("€60K+", "up to $90k").
+type Salary struct {
+	Min, Max float64
+	Currency string // ISO 4217 code, empty when the posting doesn't say
+	Period   Period
+}
+
+// IsZero reports whether no amount was found
+func (s Salary) IsZero() bool {
+	return s.Min == 0 && s.Max == 0
+}
+
+// Annual returns the range converted to yearly amounts, rounded to whole units
+func (s Salary) Annual() (minSal, maxSal int) {
+	factor, ok := periodsPerYear[s.Period]
+	if !ok {
+		factor = 1
+	}
+	return int(math.Round(s.Min * factor)), int(math.Round(s.Max * factor))
+}
+
+var (
+	// Amounts like "120,000", "45.000", "1.5", "60" with an optional k or M
+	// suffix; other letters right after the digits ("3yrs", "2nd") mean it
+	// isn't an amount
+	amountRegex = regexp.MustCompile(`(?i)(\d+(?:[.,]\d+)*)(?:\s*(k|m)\b|([a-z]+))?`)
+	// US retirement plans ("401k", "403(b)") read like amounts
+	retirementRegex = regexp.MustCompile(`(?i)\b40[13]\s*\(?[kb]\)?`)
+	codeRegex       = regexp.MustCompile(`\b(USD|EUR|GBP|CAD|AUD|NZD|CHF|SEK|NOK|DKK|PLN|CZK|INR|SGD|JPY|BRL|MXN|ZAR|ILS)\b`)
+
+	hourlyRegex  = regexp.MustCompile(`(?i)/\s*h(ou)?r?\b|\bper\s+hour\b|\ban\s+hour\b|\bhourly\b|\bp/h\b`)
+	dailyRegex   = regexp.MustCompile(`(?i)/\s*day\b|\bper\s+day\b|\ba\s+day\b|\bdaily\b|\bday\s+rate\b`)
+	weeklyRegex  = regexp.MustCompile(`(?i)/\s*w(ee)?k\b|\bper\s+week\b|\ba\s+week\b|\bweekly\b`)
+	monthlyRegex = regexp.MustCompile(`(?i)/\s*mo(nth)?\b|\bper\s+month\b|\ba\s+month\b|\bmonthly\b`)
+	yearlyRegex  = regexp.MustCompile(`(?i)/\s*y(ea)?r\b|\bper\s+(year|annum)\b|\ba\s+year\b|\b(yearly|annual|annually)\b|\bp\.?a\.?\b`)
+
+	upToRegex = regexp.MustCompile(`(?i)\b(up\s+to|max(imum)?)\b|<`)
+	fromRegex = regexp.MustCompile(`(?i)\b(from|min(imum)?|starting(\s+at)?)\b|\+|>`)
+)
+
+// Currency symbols, longest first so "CA$" wins over "$"
+var currencySymbols = []struct{ symbol, code string }{
+	{"US$", "USD"}, {"CA$", "CAD"}, {"C$", "CAD"}, {"AU$", "AUD"}, {"A$", "AUD"},
+	{"NZ$", "NZD"}, {"S$", "SGD"}, {"R$", "BRL"},
+	{"$", "USD"}, {"€", "EUR"}, {"£", "GBP"}, {"₹", "INR"}, {"¥", "JPY"}, {"₪", "ILS"},
+}
+
+// Parse reads a free-text salary such as "$120,000 – $150,000", "€60K+",
+// "80k-100k USD", "₹1.5M" or "£45/hr". The period defaults to yearly; a small amount
+// with no stated period is taken as hourly. A zero Salary means nothing
+// usable was found.
+func Parse(s string) Salary {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Salary{}
+	}
+
+	s = retirementRegex.ReplaceAllString(s, " ")
+
+	var amounts []float64
+	var span [2]int // where the amounts are written, for detectPeriod
+	scale := 1.0
+	for _, loc := range amountRegex.FindAllStringSubmatchIndex(s, -1) {
+		m := submatches(s, loc)
+		value, ok := parseAmount(m[1])
+		if !ok || m[3] != "" {
+			continue
+		}
+		if len(amounts) == 0 {
+			span[0] = loc[0]
+		}
+		span[1] = loc[1]
+		switch strings.ToLower(m[2]) {
+		case "k":
+			value *= 1000
+			scale = 1000
+		case "m":
+			value *= 1000000
+			scale = 1000000
+		}
+		amounts = append(amounts, value)
+		if len(amounts) == 2 {
+			break
+		}
+	}
+	if len(amounts) == 0 {
+		return Salary{}
+	}
+	// A single k or M applies to the whole range ("150-180k", "$1-1.5M")
+	if scale > 1 {
+		for i, v := range amounts {
+			if v < 1000 {
+				amounts[i] = v * scale
+			}
+		}
+	}
+
+	out := Salary{Currency: detectCurrency(s), Period: detectPeriod(s, span)}
+	switch {
+	case len(amounts) == 2:
+		out.Min, out.Max = amounts[0], amounts[1]
+		if out.Min > out.Max {
+			out.Min, out.Max = out.Max, out.Min
+		}
+	case upToRegex.MatchString(s):
+		out.Max = amounts[0]
+	case fromRegex.MatchString(s):
+		out.Min = amounts[0]
+	default:
+		out.Min, out.Max = amounts[0], amounts[0]
+	}
+
+	top := math.Max(out.Min, out.Max)
+	if out.Period == "" {
+		switch {
+		case top <= 500 && out.Currency != "":
+			out.Period = Hourly
+		case top < 1000:
+			// Bare small numbers are not salaries ("5 years", "Series A 20")
+			return Salary{}
+		default:
+			out.Period = Yearly
+		}
+	}
+	return out
+}
+
+// ParsePeriod maps an ATS pay interval ("per-hour-wage", "1 YEAR", "month")
+// to a Period, empty when it isn't one
+func ParsePeriod(interval string) Period {
+	interval = strings.ToLower(interval)
+	switch {
+	case strings.Contains(interval, "hour"):
+		return Hourly
+	case strings.Contains(interval, "day"), strings.Contains(interval, "daily"):
+		return Daily
+	case strings.Contains(interval, "week"):
+		return Weekly
+	case strings.Contains(interval, "month"):
+		return Monthly
+	case strings.Contains(interval, "year"), strings.Contains(interval, "annual"):
+		return Yearly
+	default:
+		return ""
+	}
+}
+
+func detectCurrency(s string) string {
+	if m := codeRegex.FindString(strings.ToUpper(s)); m != "" {
+		return m
+	}
+	for _, c := range currencySymbols {
+		if strings.Contains(s, c.symbol) {
+			return c.code
+		}
+	}
+	return ""
+}
+
+// Period patterns, checked in this order when two are equally close
+var periodRegexes = []struct {
+	period Period
+	re     *regexp.Regexp
+}{
+	{Hourly, hourlyRegex}, {Daily, dailyRegex}, {Weekly, weeklyRegex},
+	{Monthly, monthlyRegex}, {Yearly, yearlyRegex},
+}
+
+// detectPeriod returns the period mentioned closest to the amounts at span, so
+// "$100k per year, 5 days a week" stays yearly
+func detectPeriod(s string, span [2]int) Period {
+	var best Period
+	bestDist := -1
+	for _, p := range periodRegexes {
+		for _, loc := range p.re.FindAllStringIndex(s, -1) {
+			dist := 0
+			switch {
+			case loc[0] >= span[1]:
+				dist = loc[0] - span[1]
+			case loc[1] <= span[0]:
+				dist = span[0] - loc[1]
+			}
+			if bestDist < 0 || dist < bestDist {
+				best, bestDist = p.period, dist
+			}
+		}
+	}
+	return best
+}
+
+// submatches returns the groups of a FindAllStringSubmatchIndex match, empty
+// for groups that didn't take part
+func submatches(s string, loc []int) []string {
+	out := make([]string, len(loc)/2)
+	for i := range out {
+		if loc[2*i] >= 0 {
+			out[i] = s[loc[2*i]:loc[2*i+1]]
+		}
+	}
+	return out
+}
+
+// parseAmount reads a number written with either "," or "." as the thousands
+// separator ("120,000", "45.000", "12,00,000") or as the decimal point ("1.5")
+func parseAmount(raw string) (float64, bool) {
+	lastDot, lastComma := strings.LastIndex(raw, "."), strings.LastIndex(raw, ",")
+
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		// Both used: the later one is the decimal point
+		if lastDot > lastComma {
+			raw = strings.ReplaceAll(raw, ",", "")
+		} else {
+			raw = strings.ReplaceAll(strings.ReplaceAll(raw, ".", ""), ",", ".")
+		}
+	case lastDot >= 0 || lastComma >= 0:
+		sep := "."
+		if lastComma >= 0 {
+			sep = ","
+		}
+		// Thousands separators end on a group of three; Indian numbering
+		// groups by two before that
+		groups := strings.Split(raw, sep)
+		grouped := len(groups[len(groups)-1]) == 3
+		for _, g := range groups[1 : len(groups)-1] {
+			if len(g) != 2 && len(g) != 3 {
+				grouped = false
+			}
+		}
+		if grouped {
+			raw = strings.Join(groups, "")
+		} else {
+			raw = strings.Replace(raw, sep, ".", 1)
+		}
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	return value, err == nil && value > 0
+}
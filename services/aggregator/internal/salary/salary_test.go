@@ -0,0 +1,75 @@
+package salary
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Salary
+	}{
+		{"$120,000 – $150,000", Salary{Min: 120000, Max: 150000, Currency: "USD", Period: Yearly}},
+		{"€60K+", Salary{Min: 60000, Currency: "EUR", Period: Yearly}},
+		{"up to $90k", Salary{Max: 90000, Currency: "USD", Period: Yearly}},
+		{"80k-100k USD", Salary{Min: 80000, Max: 100000, Currency: "USD", Period: Yearly}},
+		{"150-180k", Salary{Min: 150000, Max: 180000, Period: Yearly}},
+		{"£45/hr", Salary{Min: 45, Max: 45, Currency: "GBP", Period: Hourly}},
+		{"CA$70 - CA$90 per hour", Salary{Min: 70, Max: 90, Currency: "CAD", Period: Hourly}},
+		{"$8,000/month", Salary{Min: 8000, Max: 8000, Currency: "USD", Period: Monthly}},
+		{"45.000 - 55.000 EUR", Salary{Min: 45000, Max: 55000, Currency: "EUR", Period: Yearly}},
+		{"₹12,00,000 - ₹18,00,000", Salary{Min: 1200000, Max: 1800000, Currency: "INR", Period: Yearly}},
+		{"$1.5M", Salary{Min: 1500000, Max: 1500000, Currency: "USD", Period: Yearly}},
+		{"$1-1.5M", Salary{Min: 1000000, Max: 1500000, Currency: "USD", Period: Yearly}},
+		{"$150k - $120k", Salary{Min: 120000, Max: 150000, Currency: "USD", Period: Yearly}},
+		{"3yrs experience, $100k", Salary{Min: 100000, Max: 100000, Currency: "USD", Period: Yearly}},
+		{"$60", Salary{Min: 60, Max: 60, Currency: "USD", Period: Hourly}},
+		// The period next to the amount wins over other units in the text
+		{"$100,000-$120,000 per year, 5 days a week", Salary{Min: 100000, Max: 120000, Currency: "USD", Period: Yearly}},
+		{"Monthly bonus, $50/hr", Salary{Min: 50, Max: 50, Currency: "USD", Period: Hourly}},
+		{"$120k, 401(k) match", Salary{Min: 120000, Max: 120000, Currency: "USD", Period: Yearly}},
+		// Not salaries
+		{"", Salary{}},
+		{"Competitive", Salary{}},
+		{"5 years", Salary{}},
+		{"2nd round", Salary{}},
+		{"Competitive + 401k", Salary{}},
+		{"Competitive, 403b", Salary{}},
+	}
+	for _, tt := range tests {
+		if got := Parse(tt.in); got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAnnual(t *testing.T) {
+	tests := []struct {
+		in       Salary
+		min, max int
+	}{
+		{Salary{Min: 120000, Max: 150000, Period: Yearly}, 120000, 150000},
+		{Salary{Min: 50, Max: 60, Period: Hourly}, 104000, 124800},
+		{Salary{Min: 8000, Period: Monthly}, 96000, 0},
+		{Salary{Min: 100, Max: 100, Period: "fortnightly"}, 100, 100}, // unknown periods are kept as is
+	}
+	for _, tt := range tests {
+		if lo, hi := tt.in.Annual(); lo != tt.min || hi != tt.max {
+			t.Errorf("%+v.Annual() = %d, %d, want %d, %d", tt.in, lo, hi, tt.min, tt.max)
+		}
+	}
+}
+
+func TestParsePeriod(t *testing.T) {
+	for in, want := range map[string]Period{
+		"per-hour-wage": Hourly,
+		"1 YEAR":        Yearly,
+		"month":         Monthly,
+		"weekly":        Weekly,
+		"day":           Daily,
+		"":              "",
+		"commission":    "",
+	} {
+		if got := ParsePeriod(in); got != want {
+			t.Errorf("ParsePeriod(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
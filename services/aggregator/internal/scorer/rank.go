@@ -67,8 +67,12 @@ func FitScore(jobVec, skillVec []float32) float32 {
 func (wp *WorkerPool) processJob(ctx context.Context, row storage.JobRow) JobResult {
 	result := JobResult{JobID: row.ID}
 
-	// Combine title and description for embedding
+	// Fit is judged on what the job involves and asks for; company boilerplate
+	// only dilutes it. Descriptions without those sections are embedded whole.
 	text := row.Title + " " + row.Description
+	if core := row.Sections.Core(); core != "" {
+		text = row.Title + "\n\n" + core
+	}
 	if text == " " || strings.TrimSpace(text) == "" {
 		result.Error = fmt.Errorf("empty title and description")
 		return result
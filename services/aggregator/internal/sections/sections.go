@@ -0,0 +1,267 @@
+package sections
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Sections is a job description split by what each part is about, as
+// Markdown. Text before the first recognized heading counts as About.
+type Sections struct {
+	About            string `json:"about,omitempty"`
+	Responsibilities string `json:"responsibilities,omitempty"`
+	Requirements     string `json:"requirements,omitempty"`
+	NiceToHave       string `json:"nice_to_have,omitempty"`
+	Benefits         string `json:"benefits,omitempty"`
+	Compensation     string `json:"compensation,omitempty"`
+}
+
+type kind int
+
+const (
+	none kind = iota
+	about
+	responsibilities
+	requirements
+	niceToHave
+	benefits
+	compensation
+)
+
+// Heading phrases per section. A marker rule decides the heading wherever its
+// phrase appears, the first in this order winning, so "preferred
+// qualifications" is a nice-to-have and "salary and benefits" compensation.
+// Otherwise the rule with the longest matching phrase wins, so "about the
+// role" isn't About and "what you'll need" isn't "you'll" anything.
+var rules = []struct {
+	kind    kind
+	marker  bool
+	phrases []string
+}{
+	{niceToHave, true, []string{
+		"nice to have", "nice-to-have", "nice to haves", "bonus", "bonus points", "preferred",
+		"pluses", "a plus", "good to have", "extra credit", "would be great", "not required",
+	}},
+	{compensation, true, []string{
+		"compensation", "salary", "salary range", "pay", "pay range", "base pay",
+		"remuneration", "hourly rate",
+	}},
+	{benefits, false, []string{
+		"benefits", "perks", "what we offer", "we offer", "our offer", "why join", "why join us",
+		"why you'll love", "why work", "what's in it for you", "what you get", "what you'll get",
+		"how you'll grow", "how you will grow", "growth opportunities",
+	}},
+	{requirements, false, []string{
+		"requirements", "required", "qualifications", "what you bring", "what you'll bring",
+		"what we're looking for", "what we are looking for", "we're looking for", "you have",
+		"you'll have", "about you", "who you are", "must have", "must-have", "must haves",
+		"skills", "experience", "your profile", "your background", "ideal candidate",
+		"is this you", "what you'll need", "what you will need", "what you need",
+		"you should have", "what we need from you",
+	}},
+	{responsibilities, false, []string{
+		"responsibilities", "what you'll do", "what you will do", "what you'll be doing",
+		"the role", "your role", "about the role", "about the job",
+		"about the position", "the position", "the opportunity", "duties", "day to day",
+		"day-to-day", "in this role", "your mission", "your impact", "what you'll work on",
+		"key tasks", "your tasks", "the challenge",
+	}},
+	{about, false, []string{
+		"about us", "about the company", "who we are", "about", "company", "our mission",
+		"our story", "the team", "our team", "our values", "culture", "who you'll work with",
+		"who you will work with",
+	}},
+}
+
+type matcher struct {
+	kind     kind
+	marker   bool
+	contains *regexp.Regexp // phrase anywhere in a heading, longest first
+	exact    *regexp.Regexp // a plain line that is nothing but the phrase
+}
+
+var matchers = func() []matcher {
+	out := make([]matcher, 0, len(rules))
+	for _, r := range rules {
+		quoted := make([]string, len(r.phrases))
+		for i, p := range r.phrases {
+			quoted[i] = regexp.QuoteMeta(p)
+		}
+		// Alternation is leftmost-first, longer phrases go first to be found whole
+		sort.SliceStable(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+		alt := strings.Join(quoted, "|")
+		out = append(out, matcher{
+			kind:     r.kind,
+			marker:   r.marker,
+			contains: regexp.MustCompile(`\b(?:` + alt + `)\b`),
+			exact:    regexp.MustCompile(`^(?:` + alt + `)$`),
+		})
+	}
+	return out
+}()
+
+var (
+	markdownHeadingRegex = regexp.MustCompile(`^#{1,6}\s+(.+?)\s*#*$`)
+	boldLineRegex        = regexp.MustCompile(`^\*\*([^*]+?):?\*\*:?$`)
+	inlineHeadingRegex   = regexp.MustCompile(`^(?:\*\*)?([^:*]{3,40}?)(?:\*\*)?:(?:\*\*)?\s+(\S.*)$`)
+	listItemRegex        = regexp.MustCompile(`^\s*(?:[-+*]|\d+[.)])\s`)
+	headingNoiseRegex    = regexp.MustCompile(`[^\p{L}\p{N}'&+\- ]+`)
+)
+
+// normalize lower-cases heading text and drops punctuation and Markdown escapes
+func normalize(s string) string {
+	s = strings.ToLower(strings.ReplaceAll(s, `\`, ""))
+	s = strings.NewReplacer("’", "'", "‘", "'", "&", " and ").Replace(s)
+	return strings.Join(strings.Fields(headingNoiseRegex.ReplaceAllString(s, " ")), " ")
+}
+
+func classify(heading string, exact bool) kind {
+	text := normalize(heading)
+	if text == "" || len(text) > 80 {
+		return none
+	}
+	best, bestLen := none, 0
+	for _, m := range matchers {
+		longest := 0
+		if exact {
+			if m.exact.MatchString(text) {
+				longest = len(text)
+			}
+		} else {
+			for _, match := range m.contains.FindAllString(text, -1) {
+				longest = max(longest, len(match))
+			}
+		}
+		switch {
+		case longest == 0:
+		case m.marker:
+			return m.kind
+		case longest > bestLen:
+			best, bestLen = m.kind, longest
+		}
+	}
+	return best
+}
+
+// heading reports whether line starts a section, of which kind, and any
+// content following the heading on the same line. Markdown and bold headings
+// the rules don't recognize are kept as content of the current section.
+func heading(line string) (kind, string, bool) {
+	if m := markdownHeadingRegex.FindStringSubmatch(line); m != nil {
+		k := classify(m[1], false)
+		return k, "", k != none
+	}
+	if listItemRegex.MatchString(line) {
+		return none, "", false
+	}
+	if m := boldLineRegex.FindStringSubmatch(line); m != nil {
+		k := classify(m[1], false)
+		return k, "", k != none
+	}
+	if strings.HasSuffix(line, ":") && len(strings.Fields(line)) <= 8 {
+		k := classify(strings.TrimSuffix(line, ":"), false)
+		return k, "", k != none
+	}
+	if m := inlineHeadingRegex.FindStringSubmatch(line); m != nil && len(strings.Fields(m[1])) <= 5 {
+		if k := classify(m[1], false); k != none {
+			return k, m[2], true
+		}
+	}
+	if len(strings.Fields(line)) <= 6 {
+		k := classify(line, true)
+		return k, "", k != none
+	}
+	return none, "", false
+}
+
+// Split divides a Markdown description (see utils.RenderDescription) into
+// sections by its headings: Markdown headings, bold lines, short lines ending
+// in a colon or "Requirements: ..." style prefixes, and lines that are nothing
+// but a known heading such as "Benefits".
+func Split(markdown string) Sections {
+	parts := make(map[kind][]string)
+	current := about
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if k, rest, ok := heading(trimmed); ok {
+			current = k
+			if rest != "" {
+				parts[current] = append(parts[current], rest)
+			}
+			continue
+		}
+		parts[current] = append(parts[current], line)
+	}
+
+	text := func(k kind) string {
+		return strings.TrimSpace(blankRunRegex.ReplaceAllString(strings.Join(parts[k], "\n"), "\n\n"))
+	}
+	return Sections{
+		About:            text(about),
+		Responsibilities: text(responsibilities),
+		Requirements:     text(requirements),
+		NiceToHave:       text(niceToHave),
+		Benefits:         text(benefits),
+		Compensation:     text(compensation),
+	}
+}
+
+var (
+	blankRunRegex   = regexp.MustCompile(`\n\s*\n(\s*\n)+`)
+	mdImageRegex    = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)`)
+	mdLinkRegex     = regexp.MustCompile(`\[((?:\\.|[^\]])*)\]\([^)]*\)`)
+	mdLinePrefix    = regexp.MustCompile(`(?m)^\s*(?:#{1,6}\s+|[-+*]\s+|\d+[.)]\s+|>\s*)`)
+	mdEmphasisRegex = regexp.MustCompile("\\*\\*|(^|[\\s(])_|_($|[\\s).,;:!?])|`+")
+	mdEscapeRegex   = regexp.MustCompile(`\\([\\*_\x60\[\]<>#+\-.|])`)
+)
+
+// plain strips Markdown syntax, keeping one line per paragraph or list item
+func plain(md string) string {
+	s := mdImageRegex.ReplaceAllString(md, "")
+	s = mdLinkRegex.ReplaceAllString(s, "$1")
+	s = mdLinePrefix.ReplaceAllString(s, "")
+	s = mdEmphasisRegex.ReplaceAllString(s, "$1$2")
+	s = strings.ReplaceAll(s, "\\\n", "\n")
+	s = mdEscapeRegex.ReplaceAllString(s, "$1")
+	return strings.TrimSpace(s)
+}
+
+// Core is the plain text of the responsibilities and requirements, what a
+// job's fit is judged on, empty when the description has neither
+func (s Sections) Core() string {
+	var parts []string
+	for _, md := range []string{s.Responsibilities, s.Requirements} {
+		if text := plain(md); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// Value stores Sections as a JSON object
+func (s Sections) Value() (driver.Value, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan reads Sections from a JSON column, NULL being no sections
+func (s *Sections) Scan(src interface{}) error {
+	*s = Sections{}
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return fmt.Errorf("sections: cannot scan %T", src)
+	}
+}
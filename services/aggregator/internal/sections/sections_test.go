@@ -0,0 +1,168 @@
+package sections
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want Sections
+	}{
+		{
+			name: "markdown headings",
+			in: `Acme builds payment infrastructure.
+
+## What you'll do
+- Build APIs
+- Run services
+
+## Requirements
+- 5+ years of Go
+
+## Nice to have
+- Kafka
+
+## Benefits
+- Remote-first
+
+## Salary
+$150k - $180k`,
+			want: Sections{
+				About:            "Acme builds payment infrastructure.",
+				Responsibilities: "- Build APIs\n- Run services",
+				Requirements:     "- 5+ years of Go",
+				NiceToHave:       "- Kafka",
+				Benefits:         "- Remote-first",
+				Compensation:     "$150k - $180k",
+			},
+		},
+		{
+			name: "bold lines and colon headings",
+			in: `**About the role**
+You will own our billing system.
+
+**Preferred qualifications:**
+- Stripe experience
+
+What we offer:
+- Equity`,
+			want: Sections{
+				Responsibilities: "You will own our billing system.",
+				NiceToHave:       "- Stripe experience",
+				Benefits:         "- Equity",
+			},
+		},
+		{
+			name: "inline headings and bare heading lines",
+			in: `Requirements: Go and PostgreSQL.
+Benefits
+Health insurance`,
+			want: Sections{
+				Requirements: "Go and PostgreSQL.",
+				Benefits:     "Health insurance",
+			},
+		},
+		{
+			name: "unknown headings stay in the current section",
+			in: `## Who we are
+We are small.
+
+## Our tech
+Go and React.`,
+			want: Sections{
+				About: "We are small.\n\n## Our tech\nGo and React.",
+			},
+		},
+		{
+			name: "list items are never headings",
+			in: `## Responsibilities
+- Requirements: gathering them from customers`,
+			want: Sections{
+				Responsibilities: "- Requirements: gathering them from customers",
+			},
+		},
+		{
+			name: "no headings",
+			in:   "Just a short description.",
+			want: Sections{About: "Just a short description."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Split(tt.in); got != tt.want {
+				t.Errorf("Split =\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeadingKind(t *testing.T) {
+	tests := []struct {
+		heading string
+		want    kind
+	}{
+		{"## What you'll do", responsibilities},
+		{"## About the role", responsibilities},
+		{"## What you'll need", requirements},
+		{"**What You Need**", requirements},
+		{"You should have:", requirements},
+		{"## What we need from you", requirements},
+		{"## Preferred qualifications", niceToHave},
+		{"## Bonus skills", niceToHave},
+		{"## Salary & Benefits", compensation},
+		{"## How you'll grow", benefits},
+		{"## Who you'll work with", about},
+		{"## About the team", about},
+		// "you'll" alone says nothing about the section
+		{"## Where you'll be", none},
+	}
+	for _, tt := range tests {
+		if got, _, _ := heading(tt.heading); got != tt.want {
+			t.Errorf("heading(%q) = %v, want %v", tt.heading, got, tt.want)
+		}
+	}
+}
+
+func TestCore(t *testing.T) {
+	s := Sections{
+		About:            "We are Acme.",
+		Responsibilities: "- Build **APIs** with [Go](https://go.dev)\n- Review code",
+		Requirements:     "1. 5+ years of `Go`",
+	}
+	want := "Build APIs with Go\nReview code\n\n5+ years of Go"
+	if got := s.Core(); got != want {
+		t.Errorf("Core = %q, want %q", got, want)
+	}
+	if got := (Sections{About: "We are Acme."}).Core(); got != "" {
+		t.Errorf("Core without responsibilities or requirements = %q", got)
+	}
+}
+
+func TestValueScan(t *testing.T) {
+	in := Sections{About: "Acme", Requirements: "- Go"}
+	v, err := in.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw map[string]string
+	if err := json.Unmarshal([]byte(v.(string)), &raw); err != nil {
+		t.Fatal(err)
+	}
+	if len(raw) != 2 || raw["about"] != "Acme" || raw["requirements"] != "- Go" {
+		t.Errorf("Value = %s, want only about and requirements", v)
+	}
+
+	var out Sections
+	if err := out.Scan([]byte(v.(string))); err != nil || out != in {
+		t.Errorf("Scan = %+v, %v, want %+v", out, err, in)
+	}
+	if err := out.Scan(nil); err != nil || out != (Sections{}) {
+		t.Errorf("Scan(nil) = %+v, %v", out, err)
+	}
+	if err := out.Scan(42); err == nil {
+		t.Error("Scan(int) succeeded")
+	}
+}
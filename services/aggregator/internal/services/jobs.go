@@ -2,19 +2,24 @@ package services
 
 import (
 	"context"
-	"sort"
+	"errors"
 	"strings"
-	"sync"
-	"sync/atomic"
 	"time"
 
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/classify"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/company"
 	"github.com/sanchitb23/remote-job-radar/aggregator/internal/config"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/dedupe"
 	"github.com/sanchitb23/remote-job-radar/aggregator/internal/fetch"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/location"
 	"github.com/sanchitb23/remote-job-radar/aggregator/internal/logger"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/salary"
 	"github.com/sanchitb23/remote-job-radar/aggregator/internal/scorer"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/sections"
 	"github.com/sanchitb23/remote-job-radar/aggregator/internal/storage"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/tags"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/utils"
 	"go.uber.org/zap"
-	"golang.org/x/sync/errgroup"
 )
 
 type JobService struct {
@@ -22,6 +27,9 @@ type JobService struct {
 	skillVec []float32
 	timeout  time.Duration
 	config   *config.Config
+	registry *fetch.Registry
+	fxRates  salary.Rates
+	taxonomy *tags.Taxonomy
 }
 
 func (j *JobService) CleanUpJobs(ctx context.Context) error {
@@ -43,426 +51,143 @@ func (j *JobService) CleanUpJobs(ctx context.Context) error {
 	return nil
 }
 
-func NewJobService(store *storage.Store, skillVec []float32, timeout time.Duration, cfg *config.Config) *JobService {
+func NewJobService(store *storage.Store, skillVec []float32, timeout time.Duration, cfg *config.Config, registry *fetch.Registry, taxonomy *tags.Taxonomy) *JobService {
 	return &JobService{
 		store:    store,
 		skillVec: skillVec,
 		timeout:  timeout,
 		config:   cfg,
+		registry: registry,
+		fxRates:  salary.DefaultRates().With(cfg.SalaryFXRates),
+		taxonomy: taxonomy,
 	}
 }
 
-// fetchFromSources fetches jobs from specified sources, or all if sources is nil/empty
-func (j *JobService) fetchFromSources(ctx context.Context, sources []string, jobCount int) ([]storage.JobRow, error) {
-	var allJobs []storage.JobRow
+// Registry returns the job sources this service can fetch from
+func (j *JobService) Registry() *fetch.Registry {
+	return j.registry
+}
 
-	// Create a map for quick source lookup
-	sourceMap := make(map[string]bool)
-	fetchAll := len(sources) == 0
-	for _, source := range sources {
-		sourceMap[strings.ToLower(source)] = true
-	}
+// listSnapshot is the complete job list a full-list source returned in a run
+type listSnapshot struct {
+	source, prefix string
+	ids            []string
+}
 
-	// Fetch jobs from Remotive if requested or if fetching all
-	if fetchAll || sourceMap["remotive"] {
-		logger.Info("Fetching jobs from Remotive API", zap.Int("jobCount", jobCount))
-		remotiveJobs, err := fetch.Remotive(j.config.RemotiveBaseURL, jobCount)
-		if err != nil {
-			logger.Error("Remotive fetch error", zap.Error(err))
-			// Don't return here - continue with other sources
-		} else {
-			logger.Info("Retrieved jobs from Remotive", zap.Int("count", len(remotiveJobs)))
-			allJobs = append(allJobs, remotiveJobs...)
-		}
-	}
+// fetchFromSources fetches jobs from specified sources, or all if sources is nil/empty.
+// Full-list sources send conditional GETs through cache, paginated sources stop at cursors.
+// Uncapped, successful fetches of full-list sources are also returned as snapshots.
+func (j *JobService) fetchFromSources(ctx context.Context, sources []string, jobCount int, cache *fetch.ConditionalCache, cursors *fetch.Cursors) ([]storage.JobRow, []listSnapshot, error) {
+	var allJobs []storage.JobRow
+	var snapshots []listSnapshot
 
-	// Fetch jobs from Adzuna if configured and requested
-	if j.config.IsAdzunaEnabled() && (fetchAll || sourceMap["adzuna"]) {
-		adzunaJobs, err := j.fetchFromAdzuna(ctx, jobCount)
-		if err != nil {
-			logger.Error("Adzuna fetch failed", zap.Error(err))
-			// Continue with other sources
-		} else {
-			logger.Info("Retrieved jobs from Adzuna", zap.Int("count", len(adzunaJobs)))
-			allJobs = append(allJobs, adzunaJobs...)
-		}
-	} else if !j.config.IsAdzunaEnabled() && (fetchAll || sourceMap["adzuna"]) {
-		logger.Info("Adzuna API not configured, skipping")
+	resolved, unknown := j.registry.Resolve(sources)
+	if len(unknown) > 0 {
+		logger.Warn("Ignoring unknown sources",
+			zap.Strings("unknown", unknown),
+			zap.Strings("available", j.registry.Names()))
 	}
 
-	// Fetch jobs from Jooble if configured and requested
-	if j.config.IsJoobleEnabled() && (fetchAll || sourceMap["jooble"]) {
-		logger.Info("Fetching jobs from Jooble API", zap.Int("jobCount", jobCount))
-		joobleJobs, err := j.fetchFromJooble(ctx, jobCount)
-		if err != nil {
-			logger.Error("Jooble fetch error", zap.Error(err))
-			// Continue with other sources
-		} else {
-			logger.Info("Retrieved jobs from Jooble", zap.Int("count", len(joobleJobs)))
-			allJobs = append(allJobs, joobleJobs...)
-		}
-	} else if !j.config.IsJoobleEnabled() && (fetchAll || sourceMap["jooble"]) {
-		logger.Info("Jooble API not configured, skipping")
+	opts := fetch.Options{
+		JobCount: jobCount,
+		MaxPages: j.config.FetcherMaxPageNum,
+		Cache:    cache,
+		Cursors:  cursors,
 	}
+	var report fetchReport
 
-	// Fetch jobs from RemoteOK if requested or if fetching all
-	if fetchAll || sourceMap["remoteok"] {
-		logger.Info("Fetching jobs from RemoteOK API", zap.Int("jobCount", jobCount))
-		remoteokJobs, err := fetch.RemoteOK("", jobCount) // Empty baseURL will use default
-		if err != nil {
-			logger.Error("RemoteOK fetch error", zap.Error(err))
-			// Don't return here - continue with other sources
-		} else {
-			logger.Info("Retrieved jobs from RemoteOK", zap.Int("count", len(remoteokJobs)))
-			allJobs = append(allJobs, remoteokJobs...)
+	for _, source := range resolved {
+		if !source.Enabled() {
+			logger.Info("Source not configured, skipping", zap.String("source", source.Name()))
+			continue
 		}
-	}
 
-	// Fetch jobs from WWR if requested or if fetching all
-	if fetchAll || sourceMap["wwr"] {
-		logger.Info("Fetching jobs from WWR API", zap.Int("jobCount", jobCount))
-		wwrJobs, err := fetch.WWR("", jobCount) // Empty baseURL will use default
+		logger.Info("Fetching jobs from source",
+			zap.String("source", source.Name()),
+			zap.Int("jobCount", jobCount))
+		jobs, err := source.Fetch(ctx, opts)
+		report.add(source.Name(), err)
+		if errors.Is(err, fetch.ErrNotModified) {
+			logger.Info("Source unchanged since last fetch", zap.String("source", source.Name()))
+			continue
+		}
 		if err != nil {
-			logger.Error("WWR fetch error", zap.Error(err))
+			logSourceError(source.Name(), err)
 			// Don't return here - continue with other sources
-		} else {
-			logger.Info("Retrieved jobs from WWR", zap.Int("count", len(wwrJobs)))
-			allJobs = append(allJobs, wwrJobs...)
-		}
-	}
-
-	return allJobs, nil
-}
-
-// fetchFromAdzuna fetches jobs from Adzuna API with pagination
-func (j *JobService) fetchFromAdzuna(ctx context.Context, jobCount int) ([]storage.JobRow, error) {
-	logger.Info("Fetching jobs from Adzuna API", zap.Int("jobCount", jobCount))
-	var allAdzunaJobs []storage.JobRow
-
-	// Calculate how many pages we need to fetch based on jobCount
-	maxPages := j.config.FetcherMaxPageNum
-	if jobCount > 0 {
-		// Calculate pages needed: jobCount / 50 (results per page) + 1 for remainder
-		calculatedPages := (jobCount + 49) / 50 // Ceiling division
-		if calculatedPages < maxPages {
-			maxPages = calculatedPages
-		}
-		logger.Info("Calculated pages needed for job count",
-			zap.Int("jobCount", jobCount),
-			zap.Int("pagesNeeded", calculatedPages),
-			zap.Int("maxPages", maxPages))
-	}
-
-	// Fetch multiple pages from Adzuna (since it's paginated)
-	for page := 1; page <= maxPages; page++ {
-		select {
-		case <-ctx.Done():
-			logger.Warn("Adzuna fetch interrupted by context cancellation",
-				zap.Int("page", page),
-				zap.Int("jobsFetched", len(allAdzunaJobs)))
-			return allAdzunaJobs, ctx.Err()
-		default:
+			continue
 		}
 
-		// Create a timeout for individual page fetch
-		pageCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
-		adzunaJobs, err := fetch.Adzuna(pageCtx, page, j.config.AdzunaAppID, j.config.AdzunaAppKey, j.config.AdzunaBaseURL, jobCount)
-		cancel()
+		logger.Info("Retrieved jobs from source",
+			zap.String("source", source.Name()),
+			zap.Int("count", len(jobs)))
+		allJobs = append(allJobs, jobs...)
 
-		if err != nil {
-			logger.Error("Adzuna fetch error", zap.Error(err), zap.Int("page", page))
-			// Continue with what we have if we got some results
-			if len(allAdzunaJobs) > 0 {
-				logger.Info("Continuing with partial Adzuna results", zap.Int("count", len(allAdzunaJobs)))
-				break
+		// An empty list is more likely a broken response than every job closing
+		if full, ok := source.(fetch.FullListSource); ok && full.ListPrefix() != "" && jobCount == 0 && len(jobs) > 0 {
+			snap := listSnapshot{source: source.Name(), prefix: full.ListPrefix()}
+			for _, job := range jobs {
+				snap.ids = append(snap.ids, job.ID)
 			}
-			return nil, err
-		}
-
-		logger.Info("Retrieved jobs from Adzuna",
-			zap.Int("count", len(adzunaJobs)),
-			zap.Int("page", page))
-		allAdzunaJobs = append(allAdzunaJobs, adzunaJobs...)
-
-		// Break early if we get fewer results than expected (last page)
-		// or if we've reached the job count limit
-		if len(adzunaJobs) < 50 || (jobCount > 0 && len(allAdzunaJobs) >= jobCount) {
-			break
+			snapshots = append(snapshots, snap)
 		}
 	}
 
-	// Limit the total jobs if jobCount is specified
-	if jobCount > 0 && len(allAdzunaJobs) > jobCount {
-		allAdzunaJobs = allAdzunaJobs[:jobCount]
-	}
-
-	logger.Info("Total jobs fetched from Adzuna", zap.Int("count", len(allAdzunaJobs)))
-	return allAdzunaJobs, nil
+	report.log()
+	return allJobs, snapshots, nil
 }
 
-// getAdaptiveSearchStrategy adjusts search strategy based on job count and time constraints
-func (j *JobService) getAdaptiveSearchStrategy(jobCount int) (int, []struct {
-	keyword  string
-	location string
-	weight   int
-}) {
-	// Get base queries
-	baseQueries := j.getOptimizedSearchQueries()
-
-	// Adaptive strategy based on job count
-	if jobCount > 0 {
-		if jobCount <= 50 {
-			// Small job count: focus on high-priority queries only
-			return 1, baseQueries[:3] // Top 3 queries
-		} else if jobCount <= 200 {
-			// Medium job count: use moderate number of queries
-			return 2, baseQueries[:6] // Top 6 queries
-		}
-		// Large job count: use all queries
-		return j.config.JoobleConcurrency, baseQueries
-	}
-
-	// No job count limit: use all queries with full concurrency
-	return j.config.JoobleConcurrency, baseQueries
-}
-
-// getOptimizedSearchQueries returns weighted search queries for optimal job discovery
-func (j *JobService) getOptimizedSearchQueries() []struct {
-	keyword  string
-	location string
-	weight   int
-} {
-	// Base search queries with weights (higher weight = search first)
-	baseQueries := []struct {
-		keyword  string
-		location string
-		weight   int
-	}{
-		{"*", "remote", 10}, // Most general, highest priority
-		{"engineer", "remote", 9},
-		{"developer", "remote", 9},
-		{"software", "remote", 8},
-		{"*", "india", 7},
-		{"engineer", "india", 6},
-		{"developer", "india", 6},
-		{"*", "united states", 5},
-		{"*", "europe", 4},
-	}
-
-	// Sort by weight (highest first)
-	sort.Slice(baseQueries, func(i, j int) bool {
-		return baseQueries[i].weight > baseQueries[j].weight
-	})
-
-	return baseQueries
+// fetchReport groups the sources of a fetch run by outcome
+type fetchReport struct {
+	succeeded []string
+	unchanged []string
+	failed    map[fetch.ErrorKind][]string
 }
 
-// fetchFromJooble fetches jobs from Jooble API with pagination & multiple keywords/locations
-func (j *JobService) fetchFromJooble(ctx context.Context, jobCount int) ([]storage.JobRow, error) {
-	logger.Info("Fetching jobs from Jooble API", zap.Int("jobCount", jobCount))
-
-	// Pre-allocate slice with estimated capacity to reduce memory allocations
-	estimatedCapacity := jobCount
-	if estimatedCapacity == 0 {
-		estimatedCapacity = j.config.FetcherMaxPageNum * 50 * 4 // maxPages * pageSize * keyword/location combinations
-	}
-	allJoobleJobs := make([]storage.JobRow, 0, estimatedCapacity)
-
-	// Use sync.Map for thread-safe deduplication with better memory efficiency
-	seen := make(map[string]struct{}, estimatedCapacity)
-
-	// Get adaptive search strategy based on job count
-	concurrency, searchQueries := j.getAdaptiveSearchStrategy(jobCount)
-	logger.Info("Using adaptive search strategy",
-		zap.Int("concurrency", concurrency),
-		zap.Int("searchQueries", len(searchQueries)),
-		zap.Int("jobCount", jobCount),
-		zap.Int("maxPages", j.config.FetcherMaxPageNum))
-
-	// Calculate optimal max pages based on jobCount - distribute across queries
-	maxPages := j.config.FetcherMaxPageNum
-	if jobCount > 0 {
-		// Calculate total pages needed across all queries
-		totalPagesNeeded := (jobCount + 49) / 50 // Ceiling division
-		// Distribute pages across queries, but ensure each query gets at least 1 page
-		pagesPerQuery := totalPagesNeeded / len(searchQueries)
-		if pagesPerQuery < 1 {
-			pagesPerQuery = 1
-		}
-		if pagesPerQuery < maxPages {
-			maxPages = pagesPerQuery
-		}
-		logger.Info("Calculated pages distribution",
-			zap.Int("jobCount", jobCount),
-			zap.Int("totalPagesNeeded", totalPagesNeeded),
-			zap.Int("searchQueries", len(searchQueries)),
-			zap.Int("pagesPerQuery", pagesPerQuery),
-			zap.Int("maxPages", maxPages))
-	}
-
-	// Use buffered channel for concurrent fetching with adaptive concurrency
-	semaphore := make(chan struct{}, concurrency)
-
-	// Create a context with timeout for the entire operation
-	operationCtx, cancel := context.WithTimeout(ctx, j.config.JoobleTimeout)
-	defer cancel()
-
-	// Use errgroup for better error handling and cancellation
-	g, gCtx := errgroup.WithContext(operationCtx)
-
-	// Track successful fetches for early termination
-	var totalFetched int32
-	var mu sync.Mutex
-
-	for _, query := range searchQueries {
-		// Early termination if we have enough jobs
-		if jobCount > 0 && atomic.LoadInt32(&totalFetched) >= int32(jobCount) {
-			break
-		}
-
-		query := query // Capture for goroutine
-		g.Go(func() error {
-			return j.fetchJoobleQuery(gCtx, query.keyword, query.location, maxPages, jobCount,
-				&allJoobleJobs, seen, &totalFetched, &mu, semaphore)
-		})
+func (r *fetchReport) add(source string, err error) {
+	if err == nil {
+		r.succeeded = append(r.succeeded, source)
+		return
 	}
-
-	// Wait for all goroutines to complete
-	if err := g.Wait(); err != nil {
-		logger.Warn("Some Jooble queries failed", zap.Error(err))
-		// Continue with partial results
+	if errors.Is(err, fetch.ErrNotModified) {
+		r.unchanged = append(r.unchanged, source)
+		return
 	}
-
-	// Trim if overshot
-	if jobCount > 0 && len(allJoobleJobs) > jobCount {
-		allJoobleJobs = allJoobleJobs[:jobCount]
+	if r.failed == nil {
+		r.failed = make(map[fetch.ErrorKind][]string)
 	}
-
-	logger.Info("Total jobs fetched from Jooble",
-		zap.Int("count", len(allJoobleJobs)),
-		zap.Int("uniqueJobs", len(seen)))
-
-	return allJoobleJobs, nil
+	kind := fetch.Kind(err)
+	r.failed[kind] = append(r.failed[kind], source)
 }
 
-// fetchJoobleQuery handles fetching for a single keyword/location combination
-func (j *JobService) fetchJoobleQuery(ctx context.Context, keyword, location string, maxPages, jobCount int,
-	allJobs *[]storage.JobRow, seen map[string]struct{}, totalFetched *int32, mu *sync.Mutex, semaphore chan struct{}) error {
-
-	// Acquire semaphore to limit concurrency
-	select {
-	case semaphore <- struct{}{}:
-		defer func() { <-semaphore }()
-	case <-ctx.Done():
-		return ctx.Err()
-	}
-
-	logger.Info("Fetching Jooble jobs",
-		zap.String("keyword", keyword),
-		zap.String("location", location))
-
-	var queryJobs []storage.JobRow
-	seenLocal := make(map[string]struct{}) // Local deduplication for this query
-
-	for page := 1; page <= maxPages; page++ {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-		}
-
-		// Check if we've already reached the global job count limit
-		if jobCount > 0 && atomic.LoadInt32(totalFetched) >= int32(jobCount) {
-			break
-		}
-
-		// Per-page timeout with exponential backoff for retries
-		pageCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
-		joobleJobs, err := fetch.Jooble(pageCtx, page, j.config.JoobleAPIKey, keyword, location, jobCount)
-		cancel()
-
-		if err != nil {
-			logger.Error("Jooble fetch error",
-				zap.Error(err),
-				zap.String("keyword", keyword),
-				zap.String("location", location),
-				zap.Int("page", page))
-
-			// Implement exponential backoff for transient errors
-			if isRetryableError(err) && page < maxPages {
-				backoff := time.Duration(page) * time.Second
-				logger.Info("Retrying after backoff", zap.Duration("backoff", backoff))
-				time.Sleep(backoff)
-				continue
-			}
-			break
-		}
-
-		logger.Debug("Retrieved jobs from Jooble",
-			zap.Int("count", len(joobleJobs)),
-			zap.String("keyword", keyword),
-			zap.String("location", location),
-			zap.Int("page", page))
-
-		// Local deduplication
-		for _, job := range joobleJobs {
-			if _, exists := seenLocal[job.ID]; exists {
-				continue
-			}
-			seenLocal[job.ID] = struct{}{}
-			queryJobs = append(queryJobs, job)
-		}
-
-		// Stop if no more results or if we have enough jobs locally
-		if len(joobleJobs) < 50 {
-			break
-		}
-
-		// Check again if we've reached the job count limit after processing this page
-		if jobCount > 0 && atomic.LoadInt32(totalFetched) >= int32(jobCount) {
-			break
-		}
+func (r *fetchReport) log() {
+	fields := []zap.Field{zap.Strings("succeeded", r.succeeded), zap.Strings("unchanged", r.unchanged)}
+	for kind, sources := range r.failed {
+		fields = append(fields, zap.Strings(string(kind), sources))
 	}
-
-	// Merge results with thread-safe access and early termination
-	if len(queryJobs) > 0 {
-		mu.Lock()
-		for _, job := range queryJobs {
-			// Check if we've reached the job count limit before adding more jobs
-			if jobCount > 0 && atomic.LoadInt32(totalFetched) >= int32(jobCount) {
-				break
-			}
-			if _, exists := seen[job.ID]; !exists {
-				seen[job.ID] = struct{}{}
-				*allJobs = append(*allJobs, job)
-				atomic.AddInt32(totalFetched, 1)
-			}
-		}
-		mu.Unlock()
-	}
-
-	return nil
+	logger.Info("Fetch run report", fields...)
 }
 
-// isRetryableError determines if an error should trigger a retry
-func isRetryableError(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	errStr := err.Error()
-	// Retry on network timeouts, rate limits, and temporary server errors
-	retryablePatterns := []string{
-		"timeout", "deadline exceeded", "rate limit", "429", "503", "502", "500",
-		"connection refused", "network is unreachable",
-	}
-
-	for _, pattern := range retryablePatterns {
-		if strings.Contains(strings.ToLower(errStr), pattern) {
-			return true
+// logSourceError logs a failed source according to what went wrong, so bad
+// credentials aren't lumped in with outages
+func logSourceError(source string, err error) {
+	var rateLimitErr *fetch.RateLimitError
+	switch fetch.Kind(err) {
+	case fetch.KindAuth:
+		logger.Error("Source credentials invalid, check its API key configuration",
+			zap.String("source", source),
+			zap.Error(err))
+	case fetch.KindRateLimit:
+		fields := []zap.Field{zap.String("source", source), zap.Error(err)}
+		if errors.As(err, &rateLimitErr) && !rateLimitErr.ResetAt.IsZero() {
+			fields = append(fields, zap.Time("resetAt", rateLimitErr.ResetAt))
 		}
+		logger.Warn("Source rate limited, skipping until next run", fields...)
+	default:
+		logger.Error("Source fetch error",
+			zap.String("source", source),
+			zap.String("kind", string(fetch.Kind(err))),
+			zap.Error(err))
 	}
-	return false
 }
 
 func (j *JobService) FetchAndProcessJobs(ctx context.Context) error {
@@ -488,13 +213,17 @@ func (j *JobService) FetchAndProcessJobsFromSources(ctx context.Context, sources
 	defer cancel()
 
 	// Fetch jobs from specified sources (or all if sources is nil)
-	allJobs, err := j.fetchFromSources(fetchCtx, sources, jobCount)
+	cache := fetch.NewConditionalCache(j.store)
+	cursors := fetch.NewCursors(j.store)
+	allJobs, snapshots, err := j.fetchFromSources(fetchCtx, sources, jobCount, cache, cursors)
 	if err != nil {
 		logger.Error("Error fetching from sources", zap.Error(err))
 		return err
 	}
 
+	// Unchanged (304) sources contribute nothing, so an all-unchanged run skips upsert and scoring
 	if len(allJobs) == 0 {
+		j.commitRunState(cache, cursors)
 		if len(sources) > 0 {
 			logger.Warn("No jobs fetched from specified sources", zap.Strings("sources", sources))
 		} else {
@@ -503,6 +232,16 @@ func (j *JobService) FetchAndProcessJobsFromSources(ctx context.Context, sources
 		return nil
 	}
 
+	hashContent(allJobs)
+	renderDescriptions(allJobs)
+	checkPublishedDates(allJobs, time.Now())
+	normalizeCompanies(allJobs)
+	j.convertSalaries(allJobs)
+	normalizeLocations(allJobs)
+	classifyJobs(allJobs)
+	j.extractTags(allJobs)
+	fingerprintJobs(allJobs)
+
 	// Store all jobs in database
 	logger.Info("Upserting jobs to database", zap.Int("totalJobs", len(allJobs)))
 
@@ -514,6 +253,8 @@ func (j *JobService) FetchAndProcessJobsFromSources(ctx context.Context, sources
 		logger.Error("Database error", zap.Error(err))
 		return err
 	}
+	j.commitRunState(cache, cursors)
+	j.closeMissingJobs(dbCtx, snapshots)
 
 	duration := time.Since(startTime)
 	if len(sources) > 0 {
@@ -539,6 +280,314 @@ func (j *JobService) FetchAndProcessJobsFromSources(ctx context.Context, sources
 		return err
 	}
 
+	// Closed postings leave their clusters, so liveness checks go first
+	j.CheckLiveness(scoreCtx)
+
+	// Clustering compares embeddings, so it runs once the new jobs have theirs
+	if err := j.ClusterDuplicates(scoreCtx); err != nil {
+		logger.Error("Duplicate clustering error", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// hashContent fingerprints each job as the source sent it, before anything
+// below rewrites its fields
+func hashContent(jobs []storage.JobRow) {
+	for i := range jobs {
+		jobs[i].ContentHash = storage.ContentHash(jobs[i])
+	}
+}
+
+// renderDescriptions turns each source description into the Markdown shown in
+// the app and the plain text everything else (classification, tags, dedupe,
+// embeddings) works on, and splits the Markdown into sections
+func renderDescriptions(jobs []storage.JobRow) {
+	for i := range jobs {
+		job := &jobs[i]
+		job.DescriptionMarkdown, job.Description = utils.RenderDescription(job.Description)
+		job.Sections = sections.Split(job.DescriptionMarkdown)
+	}
+}
+
+// checkPublishedDates reports publish dates the sources sent that couldn't be
+// read, and drops ones too far in the future. Either way the job is stored
+// dated when first seen instead of failing the upsert.
+func checkPublishedDates(jobs []storage.JobRow, now time.Time) {
+	type problem struct {
+		count  int
+		sample string
+	}
+	unreadable := make(map[string]*problem)
+	future := make(map[string]*problem)
+	record := func(problems map[string]*problem, source, value string) {
+		p, ok := problems[source]
+		if !ok {
+			p = &problem{sample: value}
+			problems[source] = p
+		}
+		p.count++
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		switch {
+		case job.PublishedAt.IsZero() && strings.TrimSpace(job.PublishedRaw) != "":
+			record(unreadable, job.Source, job.PublishedRaw)
+		case job.PublishedAt.After(now.Add(fetch.MaxPublishedSkew)):
+			record(future, job.Source, job.PublishedAt.Format(time.RFC3339))
+			job.PublishedAt = time.Time{}
+		}
+	}
+
+	for source, p := range unreadable {
+		logger.Warn("Unreadable publish dates, jobs dated when first seen",
+			zap.String("source", source),
+			zap.Int("jobs", p.count),
+			zap.String("sample", p.sample))
+	}
+	for source, p := range future {
+		logger.Warn("Publish dates in the future, jobs dated when first seen",
+			zap.String("source", source),
+			zap.Int("jobs", p.count),
+			zap.String("sample", p.sample))
+	}
+}
+
+// normalizeCompanies derives the key jobs are linked to their company by, and
+// the company's website domain from its URL or, failing that, a job URL that
+// isn't on a job board or ATS
+func normalizeCompanies(jobs []storage.JobRow) {
+	for i := range jobs {
+		job := &jobs[i]
+		job.Company = company.Display(job.Company)
+		job.CompanyKey = company.Key(job.Company)
+		job.CompanyDomain = company.Domain(job.CompanyURL)
+		if job.CompanyDomain == "" {
+			job.CompanyDomain = company.Domain(job.URL)
+		}
+	}
+}
+
+// convertSalaries fills the annual US dollar equivalent of every salary whose
+// currency has an FX rate, so salaries can be filtered across currencies
+func (j *JobService) convertSalaries(jobs []storage.JobRow) {
+	missing := make(map[string]int)
+	for i := range jobs {
+		job := &jobs[i]
+		if job.SalaryMin == 0 && job.SalaryMax == 0 {
+			continue
+		}
+		minUSD, ok := j.fxRates.ToUSD(job.SalaryMin, job.SalaryCurrency)
+		if !ok {
+			missing[job.SalaryCurrency]++
+			continue
+		}
+		job.SalaryMinUSD = minUSD
+		job.SalaryMaxUSD, _ = j.fxRates.ToUSD(job.SalaryMax, job.SalaryCurrency)
+	}
+
+	for currency, count := range missing {
+		if currency == "" {
+			currency = "unknown"
+		}
+		logger.Warn("No FX rate for salary currency, USD salary left empty",
+			zap.String("currency", currency),
+			zap.Int("jobs", count))
+	}
+}
+
+// normalizeLocations resolves each job's free-text location into workplace
+// type, countries, regions and eligibility restrictions. The location text
+// wins over the source's workplace hint.
+func normalizeLocations(jobs []storage.JobRow) {
+	for i := range jobs {
+		job := &jobs[i]
+		loc := location.Parse(job.Location)
+		if loc.Workplace == "" {
+			loc.Workplace = location.ParseWorkplace(job.WorkplaceType)
+		}
+
+		job.WorkplaceType = string(loc.Workplace)
+		job.Countries = loc.Countries
+		job.Regions = loc.Regions
+		job.RemoteAnywhere = loc.Anywhere
+		job.Eligibility = loc.Eligibility
+		job.UTCOffsetMin, job.UTCOffsetMax = loc.UTCOffsetMin, loc.UTCOffsetMax
+	}
+}
+
+// classifyJobs derives seniority, employment type and role family from the
+// title, description and the source's own category/type in WorkType
+func classifyJobs(jobs []storage.JobRow) {
+	for i := range jobs {
+		job := &jobs[i]
+		res := classify.Classify(classify.Job{
+			Title:       job.Title,
+			Description: job.Description,
+			Hint:        job.WorkType,
+		})
+		job.Seniority, job.EmploymentType, job.RoleFamily = res.Seniority, res.EmploymentType, res.RoleFamily
+	}
+}
+
+// extractTags keeps every tag the source gave, normalized through the taxonomy,
+// and adds the technologies mentioned in the title and description
+func (j *JobService) extractTags(jobs []storage.JobRow) {
+	for i := range jobs {
+		job := &jobs[i]
+		job.Tags = nil
+		for _, t := range j.taxonomy.Extract(job.SourceTags, job.Title, job.Description) {
+			job.Tags = append(job.Tags, storage.JobTag{Tag: t.Name, Origin: t.Origin})
+		}
+	}
+}
+
+// fingerprintJobs computes the description simhash duplicates are matched on
+func fingerprintJobs(jobs []storage.JobRow) {
+	for i := range jobs {
+		jobs[i].SimHash = dedupe.SimHash(jobs[i].Description)
+	}
+}
+
+// commitRunState saves the ETag/Last-Modified values and source cursors of this
+// run once its jobs are stored
+func (j *JobService) commitRunState(cache *fetch.ConditionalCache, cursors *fetch.Cursors) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := cache.Commit(ctx); err != nil {
+		// Next run just fetches the full lists again
+		logger.Warn("Failed to save HTTP validators", zap.Error(err))
+	}
+	if err := cursors.Commit(ctx); err != nil {
+		// Next run just pages further than needed
+		logger.Warn("Failed to save source cursors", zap.Error(err))
+	}
+}
+
+// closeMissingJobs closes jobs full-list sources stopped returning for
+// ExpiryMissedRuns runs in a row, and reopens those that came back
+func (j *JobService) closeMissingJobs(ctx context.Context, snapshots []listSnapshot) {
+	for _, snap := range snapshots {
+		closed, err := j.store.MarkListed(ctx, snap.prefix, snap.ids, j.config.ExpiryMissedRuns)
+		if err != nil {
+			logger.Error("Failed to expire missing jobs", zap.String("source", snap.source), zap.Error(err))
+			continue
+		}
+		if closed > 0 {
+			logger.Info("Closed jobs no longer listed by source",
+				zap.String("source", snap.source),
+				zap.Int64("closed", closed))
+		}
+	}
+}
+
+// CheckLiveness requests the posting URLs of up to ExpiryLivenessBatch open
+// jobs from sources that aren't full lists and closes those that are gone
+// (404/410) or say they no longer accept applications. Failed checks are
+// retried on a later run.
+func (j *JobService) CheckLiveness(ctx context.Context) {
+	if j.config.ExpiryLivenessBatch <= 0 {
+		return
+	}
+	startTime := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, j.config.ExpiryLivenessTimeout)
+	defer cancel()
+
+	var skip []string
+	all, _ := j.registry.Resolve(nil)
+	for _, source := range all {
+		if full, ok := source.(fetch.FullListSource); ok && full.ListPrefix() != "" {
+			skip = append(skip, full.ListPrefix())
+		}
+	}
+
+	rows, err := j.store.FetchLivenessCandidates(ctx, skip, j.config.ExpiryLivenessInterval, j.config.ExpiryLivenessBatch)
+	if err != nil {
+		logger.Error("Failed to load jobs for liveness check", zap.Error(err))
+		return
+	}
+
+	var checked, closed, failed int
+	for _, row := range rows {
+		isClosed, err := fetch.CheckLiveness(ctx, row.URL)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			failed++
+			logger.Debug("Liveness check failed", zap.String("jobId", row.ID), zap.Error(err))
+			// Recorded as checked, so URLs that keep failing (bot protection,
+			// timeouts) wait an interval instead of taking the whole batch
+			isClosed = false
+		}
+		if err := j.store.SaveLiveness(ctx, row.ID, isClosed); err != nil {
+			logger.Error("Failed to save liveness check", zap.String("jobId", row.ID), zap.Error(err))
+			continue
+		}
+		checked++
+		if isClosed {
+			closed++
+		}
+	}
+
+	if ctx.Err() != nil {
+		logger.Warn("Liveness check stopped at its time limit", zap.Duration("timeout", j.config.ExpiryLivenessTimeout))
+	}
+	logger.Info("Liveness check completed",
+		zap.Int("checked", checked),
+		zap.Int("closed", closed),
+		zap.Int("failed", failed),
+		zap.Duration("duration", time.Since(startTime)))
+}
+
+// ClusterDuplicates groups copies of the same job posted on several sources.
+// The richest copy of each cluster stays primary, the others are flagged
+// is_duplicate and keep their own source link.
+func (j *JobService) ClusterDuplicates(ctx context.Context) error {
+	startTime := time.Now()
+
+	rows, err := j.store.FetchClusterCandidates(ctx)
+	if err != nil {
+		return err
+	}
+
+	cands := make([]dedupe.Candidate, len(rows))
+	for i, r := range rows {
+		cands[i] = dedupe.Candidate{
+			ID:                r.ID,
+			Source:            r.Source,
+			CompanyID:         r.CompanyID,
+			Title:             r.Title,
+			SimHash:           uint64(r.SimHash.Int64),
+			Vector:            r.Vector,
+			DescriptionLength: r.DescriptionLength,
+			HasSalary:         r.HasSalary,
+			HasLocation:       r.HasLocation,
+			TagCount:          r.TagCount,
+			PublishedAt:       r.PublishedAt,
+		}
+		if !r.SimHash.Valid {
+			cands[i].SimHash = dedupe.SimHash(r.Description)
+		}
+	}
+
+	primaries := dedupe.Cluster(cands)
+	if err := j.store.SaveClusters(ctx, primaries); err != nil {
+		return err
+	}
+
+	clusters := make(map[string]bool)
+	for _, primary := range primaries {
+		clusters[primary] = true
+	}
+	logger.Info("Duplicate clustering completed",
+		zap.Int("jobs", len(cands)),
+		zap.Int("clusters", len(clusters)),
+		zap.Int("duplicates", len(primaries)-len(clusters)),
+		zap.Duration("duration", time.Since(startTime)))
 	return nil
 }
 
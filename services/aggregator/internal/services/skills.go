@@ -27,7 +27,8 @@ func NewSkillsService(embedder *scorer.Embedder, skillsFile string) *SkillsServi
 	}
 }
 
-func (s *SkillsService) LoadSkillVector(ctx context.Context) ([]float32, error) {
+// LoadSkills reads the skill list from the skills file
+func (s *SkillsService) LoadSkills() ([]string, error) {
 	logger.Info("Loading skills from file", zap.String("file", s.skillsFile))
 
 	buf, err := os.ReadFile(s.skillsFile)
@@ -46,7 +47,16 @@ func (s *SkillsService) LoadSkillVector(ctx context.Context) ([]float32, error)
 		zap.Int("count", len(sf.Skills)),
 		zap.Strings("skills", sf.Skills))
 
-	skillsText := strings.Join(sf.Skills, " ")
+	return sf.Skills, nil
+}
+
+func (s *SkillsService) LoadSkillVector(ctx context.Context) ([]float32, error) {
+	skills, err := s.LoadSkills()
+	if err != nil {
+		return nil, err
+	}
+
+	skillsText := strings.Join(skills, " ")
 
 	logger.Info("Generating embeddings for skills")
 	emb, err := s.embedder.Embed(ctx, skillsText)
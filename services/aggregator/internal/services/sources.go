@@ -0,0 +1,123 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/config"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/fetch"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/logger"
+	"go.uber.org/zap"
+)
+
+// NewFetchClient builds the rate-limited, retrying HTTP client shared by all job
+// sources. FETCH_HTTP_MODE=record saves every source response to the fixtures
+// directory, replay serves them back without touching the network.
+func NewFetchClient(cfg *config.Config) (*fetch.Client, error) {
+	clientCfg := fetch.ClientConfig{
+		UserAgent:      cfg.FetchUserAgent,
+		RequestTimeout: cfg.FetchRequestTimeout,
+		MaxRetries:     cfg.FetchMaxRetries,
+		BaseDelay:      cfg.FetchRetryBaseDelay,
+		MaxDelay:       cfg.FetchRetryMaxDelay,
+		RateLimit:      cfg.FetchRateLimit,
+		RateBurst:      cfg.FetchRateBurst,
+		HostRateLimits: cfg.FetchHostRateLimits,
+	}
+
+	// API keys end up in URLs, keep them out of fixtures
+	secrets := []string{cfg.AdzunaAppID, cfg.AdzunaAppKey, cfg.JoobleAPIKey}
+
+	switch cfg.FetchHTTPMode {
+	case "", fetch.HTTPModeLive:
+	case fetch.HTTPModeRecord:
+		transport, err := fetch.NewRecordTransport(cfg.FetchFixturesDir, nil, secrets)
+		if err != nil {
+			return nil, err
+		}
+		clientCfg.Transport = transport
+	case fetch.HTTPModeReplay:
+		transport, err := fetch.NewReplayTransport(cfg.FetchFixturesDir, secrets)
+		if err != nil {
+			return nil, err
+		}
+		clientCfg.Transport = transport
+		// Recorded responses are served instantly and retrying a missing one won't help
+		clientCfg.RateLimit = 0
+		clientCfg.MaxRetries = 0
+	default:
+		return nil, fmt.Errorf("unknown FETCH_HTTP_MODE %q, expected live, record or replay", cfg.FetchHTTPMode)
+	}
+
+	if cfg.FetchHTTPMode == fetch.HTTPModeRecord || cfg.FetchHTTPMode == fetch.HTTPModeReplay {
+		logger.Info("Source HTTP traffic redirected",
+			zap.String("mode", cfg.FetchHTTPMode),
+			zap.String("fixturesDir", cfg.FetchFixturesDir))
+	}
+	return fetch.NewClient(clientCfg), nil
+}
+
+// NewSourceRegistry registers every job source the aggregator knows about.
+// Sources without credentials are still registered and report Enabled() == false.
+// skills feeds keyword search for sources that support it.
+func NewSourceRegistry(cfg *config.Config, skills []string) (*fetch.Registry, error) {
+	registry, err := fetch.NewRegistry(
+		fetch.NewRemotiveSource(cfg.RemotiveBaseURL),
+		fetch.NewAdzunaSource(cfg.AdzunaBaseURL, cfg.AdzunaAppID, cfg.AdzunaAppKey, cfg.AdzunaCountries, adzunaQuery(cfg, skills)),
+		fetch.NewJoobleSource(cfg.JoobleAPIKey, cfg.JoobleConcurrency, cfg.JoobleTimeout, cfg.JoobleQueriesFile, skills),
+		fetch.NewRemoteOKSource(""),
+		fetch.NewWWRSource(""),
+		fetch.NewGreenhouseSource(cfg.GreenhouseBaseURL, cfg.GreenhouseBoards),
+		fetch.NewLeverSource(cfg.LeverBaseURL, cfg.LeverCompanies),
+		fetch.NewAshbySource(cfg.AshbyBaseURL, cfg.AshbyBoards),
+		fetch.NewWorkableSource(cfg.WorkableBaseURL, cfg.WorkableAccounts),
+		fetch.NewHackerNewsSource(cfg.HNBaseURL, cfg.HNThreadID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Declarative RSS/Atom feeds
+	if cfg.FeedsFile != "" {
+		feeds, err := fetch.LoadFeedConfigs(cfg.FeedsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load feeds: %w", err)
+		}
+		for _, feed := range feeds {
+			if err := registry.Register(fetch.NewFeedSource(feed)); err != nil {
+				return nil, fmt.Errorf("failed to register feed: %w", err)
+			}
+		}
+		logger.Info("Loaded feed sources", zap.String("file", cfg.FeedsFile), zap.Int("count", len(feeds)))
+	}
+
+	// Declarative JSON list endpoints
+	if cfg.JSONSourcesFile != "" {
+		sources, err := fetch.LoadJSONSourceConfigs(cfg.JSONSourcesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load JSON sources: %w", err)
+		}
+		for _, source := range sources {
+			if err := registry.Register(fetch.NewJSONSource(source)); err != nil {
+				return nil, fmt.Errorf("failed to register JSON source: %w", err)
+			}
+		}
+		logger.Info("Loaded JSON sources", zap.String("file", cfg.JSONSourcesFile), zap.Int("count", len(sources)))
+	}
+
+	return registry, nil
+}
+
+// adzunaQuery builds the Adzuna search filters; what_or falls back to the skills profile
+func adzunaQuery(cfg *config.Config, skills []string) fetch.AdzunaQuery {
+	whatOr := cfg.AdzunaWhatOr
+	if whatOr == "" {
+		whatOr = strings.Join(skills, " ")
+	}
+	return fetch.AdzunaQuery{
+		What:       cfg.AdzunaWhat,
+		WhatOr:     whatOr,
+		Where:      cfg.AdzunaWhere,
+		MaxDaysOld: cfg.AdzunaMaxDaysOld,
+	}
+}
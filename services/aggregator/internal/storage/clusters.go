@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ClusterRow is a job as duplicate clustering reads it. Description is only
+// loaded for jobs stored before simhash was, so it can be computed.
+type ClusterRow struct {
+	ID, Source, CompanyID, Title string
+	SimHash                      sql.NullInt64
+	Description                  string
+	Vector                       pq.Float32Array
+	DescriptionLength            int
+	HasSalary, HasLocation       bool
+	TagCount                     int
+	PublishedAt                  time.Time
+}
+
+// FetchClusterCandidates returns every open job linked to a company, the only
+// ones duplicates are looked for among
+func (s *Store) FetchClusterCandidates(ctx context.Context) ([]ClusterRow, error) {
+	rows, err := s.DB.QueryContext(ctx, `SELECT j.id, j.source, j.company_id, j.title, j.simhash,
+		CASE WHEN j.simhash IS NULL THEN j.description ELSE '' END,
+		j.vector::real[], length(j.description),
+		COALESCE(j.salary_min, 0) > 0 OR COALESCE(j.salary_max, 0) > 0,
+		COALESCE(j.location, '') <> '',
+		(SELECT COUNT(*) FROM job_tags t WHERE t.job_id = j.id),
+		j.published_at
+		FROM jobs j
+		WHERE j.company_id IS NOT NULL AND j.closed_at IS NULL`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []ClusterRow
+	for rows.Next() {
+		var r ClusterRow
+		if err := rows.Scan(&r.ID, &r.Source, &r.CompanyID, &r.Title, &r.SimHash,
+			&r.Description, &r.Vector, &r.DescriptionLength, &r.HasSalary, &r.HasLocation,
+			&r.TagCount, &r.PublishedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+	return result, rows.Err()
+}
+
+// SaveClusters stores the clustering of a full run: jobs in primaries point at
+// their cluster's primary job and all but the primary are flagged duplicates,
+// every other job leaves any cluster it was in
+func (s *Store) SaveClusters(ctx context.Context, primaries map[string]string) (err error) {
+	ids := make([]string, 0, len(primaries))
+	clusters := make([]string, 0, len(primaries))
+	for id, primary := range primaries {
+		ids = append(ids, id)
+		clusters = append(clusters, primary)
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, `UPDATE jobs SET cluster_id = NULL, is_duplicate = false
+		WHERE cluster_id IS NOT NULL AND NOT (id = ANY($1))`, pq.Array(ids)); err != nil {
+		return err
+	}
+	if _, err = tx.ExecContext(ctx, `UPDATE jobs j SET cluster_id = c.cluster_id, is_duplicate = (j.id <> c.cluster_id)
+		FROM unnest($1::text[], $2::text[]) AS c(id, cluster_id)
+		WHERE j.id = c.id AND (j.cluster_id IS DISTINCT FROM c.cluster_id OR j.is_duplicate <> (j.id <> c.cluster_id))`,
+		pq.Array(ids), pq.Array(clusters)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
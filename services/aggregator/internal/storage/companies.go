@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrCompanyNotFound is returned by MergeCompanies for an unknown company id
+var ErrCompanyNotFound = errors.New("company not found")
+
+// resolveCompany returns the id of the company r.CompanyKey is an alias of,
+// creating the company on first sight. Logo and domain fill in gaps only, so
+// an earlier or manually set value isn't overwritten.
+func resolveCompany(ctx context.Context, tx *sql.Tx, r JobRow) (string, error) {
+	if r.CompanyKey == "" {
+		return "", nil
+	}
+
+	var id string
+	err := tx.QueryRowContext(ctx,
+		`SELECT company_id FROM company_aliases WHERE alias = $1`, r.CompanyKey,
+	).Scan(&id)
+	switch {
+	case err == sql.ErrNoRows:
+		id = r.CompanyKey
+		if _, err := tx.ExecContext(ctx, `INSERT INTO companies (id, name, logo_url, domain)
+			VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''))
+			ON CONFLICT (id) DO NOTHING`,
+			id, r.Company, r.CompanyLogo, r.CompanyDomain); err != nil {
+			return "", fmt.Errorf("insert company %s: %w", id, err)
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO company_aliases (alias, company_id)
+			VALUES ($1, $1) ON CONFLICT (alias) DO NOTHING`, id); err != nil {
+			return "", fmt.Errorf("insert company alias %s: %w", id, err)
+		}
+		return id, nil
+	case err != nil:
+		return "", fmt.Errorf("look up company alias %s: %w", r.CompanyKey, err)
+	}
+
+	if r.CompanyLogo != "" || r.CompanyDomain != "" {
+		if _, err := tx.ExecContext(ctx, `UPDATE companies
+			SET logo_url = COALESCE(logo_url, NULLIF($2, '')), domain = COALESCE(domain, NULLIF($3, ''))
+			WHERE id = $1 AND (logo_url IS NULL OR domain IS NULL)`,
+			id, r.CompanyLogo, r.CompanyDomain); err != nil {
+			return "", fmt.Errorf("update company %s: %w", id, err)
+		}
+	}
+	return id, nil
+}
+
+// MergeCompanies folds company from into company into: from's aliases and jobs
+// move over, missing logo/domain are taken from it, first_seen_at is the
+// earlier of the two, and from is deleted. Later postings under any of from's
+// names resolve to into.
+func (s *Store) MergeCompanies(ctx context.Context, from, into string) (err error) {
+	if from == into {
+		return fmt.Errorf("cannot merge company %s into itself", from)
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var found int
+	if err = tx.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM companies WHERE id IN ($1, $2)`, from, into,
+	).Scan(&found); err != nil {
+		return err
+	}
+	if found != 2 {
+		return ErrCompanyNotFound
+	}
+
+	stmts := []string{
+		`UPDATE companies c SET
+			logo_url = COALESCE(c.logo_url, f.logo_url),
+			domain = COALESCE(c.domain, f.domain),
+			first_seen_at = LEAST(c.first_seen_at, f.first_seen_at)
+		FROM companies f WHERE c.id = $2 AND f.id = $1`,
+		`UPDATE company_aliases SET company_id = $2 WHERE company_id = $1`,
+		`UPDATE jobs SET company_id = $2 WHERE company_id = $1`,
+		`DELETE FROM companies WHERE id = $1`,
+	}
+	for _, stmt := range stmts {
+		if _, err = tx.ExecContext(ctx, stmt, from, into); err != nil {
+			return fmt.Errorf("merge company %s into %s: %w", from, into, err)
+		}
+	}
+	return tx.Commit()
+}
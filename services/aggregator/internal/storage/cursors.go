@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// GetSourceCursor returns the newest published_at stored for a source query
+// key, zero if the key has never been fetched
+func (s *Store) GetSourceCursor(ctx context.Context, key string) (time.Time, error) {
+	var newest time.Time
+	err := s.DB.QueryRowContext(ctx,
+		`SELECT newest_published_at FROM source_cursors WHERE key = $1`, key,
+	).Scan(&newest)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	return newest, err
+}
+
+// SaveSourceCursor advances the cursor of key to newest; it never moves back
+func (s *Store) SaveSourceCursor(ctx context.Context, key string, newest time.Time) error {
+	_, err := s.DB.ExecContext(ctx, `INSERT INTO source_cursors (key, newest_published_at, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (key) DO UPDATE SET
+			newest_published_at = GREATEST(source_cursors.newest_published_at, EXCLUDED.newest_published_at),
+			updated_at = NOW()`,
+		key, newest)
+	return err
+}
@@ -2,12 +2,15 @@ package storage
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/sanchitb23/remote-job-radar/aggregator/internal/logger"
-	"github.com/sanchitb23/remote-job-radar/aggregator/internal/utils"
+	"github.com/sanchitb23/remote-job-radar/aggregator/internal/sections"
 
 	"github.com/lib/pq"
 	"go.uber.org/zap"
@@ -38,12 +41,47 @@ func (s *Store) UpsertJobs(ctx context.Context, rows []JobRow) error {
 		}
 	}()
 
-	stmt := `INSERT INTO jobs
-	(id,source,title,company,description,location,work_type,salary_min,salary_max,url,published_at)
-	VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)
-	ON CONFLICT (id) DO NOTHING`
-
-	var insertedJobs []string
+	// A changed posting (different content_hash) overwrites the stored one; a new
+	// title, description or description sections also clears the vector and fit
+	// score so it's re-scored. The CTE sees the row as it was before this
+	// statement, telling updates apart from backfilling jobs stored before
+	// content hashes or sections existed. A job without a readable publish date
+	// is dated when first seen and keeps that date. A job seen again is open
+	// again, whether or not it changed.
+	stmt := `WITH previous AS (SELECT content_hash, sections FROM jobs WHERE id = $1)
+	INSERT INTO jobs
+	(id,source,title,company,description,location,work_type,salary_min,salary_max,
+	 salary_currency,salary_period,salary_min_usd,salary_max_usd,url,published_at,
+	 workplace_type,countries,regions,remote_anywhere,eligibility,utc_offset_min,utc_offset_max,
+	 seniority,employment_type,role_family,company_id,simhash,content_hash,description_md,sections,updated_at)
+	VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,COALESCE($15::timestamptz, NOW()),$16,$17,$18,$19,$20,$21,$22,$23,$24,$25,$26,$27,$28,$29,$30::jsonb,NOW())
+	ON CONFLICT (id) DO UPDATE SET
+	 title = EXCLUDED.title, company = EXCLUDED.company, description = EXCLUDED.description,
+	 description_md = EXCLUDED.description_md, sections = EXCLUDED.sections,
+	 location = EXCLUDED.location, work_type = EXCLUDED.work_type,
+	 salary_min = EXCLUDED.salary_min, salary_max = EXCLUDED.salary_max,
+	 salary_currency = EXCLUDED.salary_currency, salary_period = EXCLUDED.salary_period,
+	 salary_min_usd = EXCLUDED.salary_min_usd, salary_max_usd = EXCLUDED.salary_max_usd,
+	 url = EXCLUDED.url, published_at = COALESCE($15::timestamptz, jobs.published_at),
+	 workplace_type = EXCLUDED.workplace_type, countries = EXCLUDED.countries, regions = EXCLUDED.regions,
+	 remote_anywhere = EXCLUDED.remote_anywhere, eligibility = EXCLUDED.eligibility,
+	 utc_offset_min = EXCLUDED.utc_offset_min, utc_offset_max = EXCLUDED.utc_offset_max,
+	 seniority = EXCLUDED.seniority, employment_type = EXCLUDED.employment_type,
+	 role_family = EXCLUDED.role_family, company_id = EXCLUDED.company_id, simhash = EXCLUDED.simhash,
+	 content_hash = EXCLUDED.content_hash, updated_at = NOW(), closed_at = NULL, missed_runs = 0,
+	 vector = CASE WHEN jobs.title IS DISTINCT FROM EXCLUDED.title OR jobs.description IS DISTINCT FROM EXCLUDED.description
+	  OR jobs.sections IS DISTINCT FROM EXCLUDED.sections THEN NULL ELSE jobs.vector END,
+	 fit_score = CASE WHEN jobs.title IS DISTINCT FROM EXCLUDED.title OR jobs.description IS DISTINCT FROM EXCLUDED.description
+	  OR jobs.sections IS DISTINCT FROM EXCLUDED.sections THEN NULL ELSE jobs.fit_score END
+	WHERE jobs.content_hash IS DISTINCT FROM EXCLUDED.content_hash OR jobs.sections IS NULL
+	RETURNING (xmax = 0), (SELECT content_hash IS NULL OR sections IS NULL FROM previous)`
+
+	tagStmt := `INSERT INTO job_tags (job_id, tag, origin)
+	SELECT $1, t.tag, t.origin FROM unnest($2::text[], $3::text[]) AS t(tag, origin)
+	ON CONFLICT (job_id, tag) DO NOTHING`
+
+	companyIDs := make(map[string]string) // company key -> company id, per run
+	var insertedJobs, updatedJobs, unchangedJobs []string
 	batchSize := 100 // Process in smaller batches to avoid overwhelming the database
 
 	for i := 0; i < len(rows); i += batchSize {
@@ -65,40 +103,89 @@ func (s *Store) UpsertJobs(ctx context.Context, rows []JobRow) error {
 			default:
 			}
 
-			// Sanitize description: convert HTML to text if needed
-			description, _ := utils.PreprocessText(r.Description, 0)
+			companyID, ok := companyIDs[r.CompanyKey]
+			if !ok {
+				if companyID, err = resolveCompany(ctx, tx, r); err != nil {
+					logger.Error("Company error: " + err.Error())
+					return err
+				}
+				companyIDs[r.CompanyKey] = companyID
+			}
 
-			result, err := tx.ExecContext(ctx, stmt,
-				r.ID, r.Source, r.Title, r.Company, description, r.Location, r.WorkType,
-				r.SalaryMin, r.SalaryMax, r.URL, r.PublishedAt)
-			if err != nil {
+			var inserted bool
+			var backfilled sql.NullBool
+			err := tx.QueryRowContext(ctx, stmt,
+				r.ID, r.Source, r.Title, r.Company, r.Description, r.Location, r.WorkType,
+				r.SalaryMin, r.SalaryMax, nullString(r.SalaryCurrency), nullString(r.SalaryPeriod),
+				nullInt(r.SalaryMinUSD), nullInt(r.SalaryMaxUSD), r.URL, nullTime(r.PublishedAt),
+				nullString(r.WorkplaceType), textArray(r.Countries), textArray(r.Regions), r.RemoteAnywhere,
+				textArray(r.Eligibility), r.UTCOffsetMin, r.UTCOffsetMax,
+				nullString(r.Seniority), nullString(r.EmploymentType), nullString(r.RoleFamily),
+				nullString(companyID), int64(r.SimHash), r.ContentHash,
+				nullString(r.DescriptionMarkdown), r.Sections,
+			).Scan(&inserted, &backfilled)
+			switch {
+			case err == sql.ErrNoRows:
+				// Unchanged since the last fetch, only reopened below
+				unchangedJobs = append(unchangedJobs, r.ID)
+			case err != nil:
 				logger.Error("Insert error: " + err.Error())
 				return err
+			case inserted:
+				insertedJobs = append(insertedJobs, r.ID)
+			default:
+				if !backfilled.Bool {
+					updatedJobs = append(updatedJobs, r.ID)
+				}
+				// Tags are re-derived from the new content
+				if _, err := tx.ExecContext(ctx, `DELETE FROM job_tags WHERE job_id = $1`, r.ID); err != nil {
+					logger.Error("Tag delete error: " + err.Error())
+					return err
+				}
 			}
 
-			// Check if this was actually inserted (not a conflict)
-			if rowsAffected, _ := result.RowsAffected(); rowsAffected > 0 {
-				insertedJobs = append(insertedJobs, r.ID)
+			if len(r.Tags) > 0 {
+				names, origins := make([]string, len(r.Tags)), make([]string, len(r.Tags))
+				for i, t := range r.Tags {
+					names[i], origins[i] = t.Tag, t.Origin
+				}
+				if _, err := tx.ExecContext(ctx, tagStmt, r.ID, pq.Array(names), pq.Array(origins)); err != nil {
+					logger.Error("Tag insert error: " + err.Error())
+					return err
+				}
 			}
 		}
 	}
 
-	// Send notifications for newly inserted jobs only
+	if _, err = tx.ExecContext(ctx, `UPDATE jobs SET closed_at = NULL, missed_runs = 0
+		WHERE id = ANY($1) AND (closed_at IS NOT NULL OR missed_runs <> 0)`, pq.Array(unchangedJobs)); err != nil {
+		logger.Error("Reopen error: " + err.Error())
+		return err
+	}
+
+	// Send notifications for newly inserted and changed jobs
 	for _, jobID := range insertedJobs {
 		if _, err := tx.ExecContext(ctx, `SELECT pg_notify('new_job', $1)`, jobID); err != nil {
 			logger.Error("NOTIFY error: " + err.Error())
 		}
 	}
+	for _, jobID := range updatedJobs {
+		if _, err := tx.ExecContext(ctx, `SELECT pg_notify('job_updated', $1)`, jobID); err != nil {
+			logger.Error("NOTIFY error: " + err.Error())
+		}
+	}
 
 	logger.Info("Successfully processed all job batches",
 		zap.Int("totalProcessed", len(rows)),
-		zap.Int("newJobs", len(insertedJobs)))
+		zap.Int("newJobs", len(insertedJobs)),
+		zap.Int("updatedJobs", len(updatedJobs)))
 
 	return tx.Commit()
 }
 
 func (s *Store) FetchRowsNeedingVector(ctx context.Context) ([]JobRow, error) {
-	stmt := `SELECT id, source, title, company, description, location, work_type, salary_min, salary_max, url, published_at
+	stmt := `SELECT id, source, title, company, description, location, work_type, salary_min, salary_max, url, published_at,
+		sections
 		FROM jobs 
 		WHERE vector IS NULL`
 
@@ -114,6 +201,7 @@ func (s *Store) FetchRowsNeedingVector(ctx context.Context) ([]JobRow, error) {
 		err := rows.Scan(
 			&row.ID, &row.Source, &row.Title, &row.Company, &row.Description,
 			&row.Location, &row.WorkType, &row.SalaryMin, &row.SalaryMax, &row.URL, &row.PublishedAt,
+			&row.Sections,
 		)
 		if err != nil {
 			return nil, err
@@ -191,10 +279,96 @@ func (s *Store) Ping(ctx context.Context) error {
 	return s.DB.PingContext(ctx)
 }
 
+// ContentHash fingerprints what the source said about a job, so a re-fetch
+// only rewrites the row when the posting changed. Only raw source fields are
+// hashed: improving the renderer or a normalizer shouldn't flag every job as
+// updated. r.Description must still be the source's own.
+func ContentHash(r JobRow) string {
+	h := sha256.New()
+	for _, field := range []string{
+		r.Title, r.Company, r.Description, r.Location, r.SalaryRaw, r.URL, publishedKey(r.PublishedAt),
+	} {
+		h.Write([]byte(field))
+		h.Write([]byte{0x1f})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// nullString stores an empty string as NULL
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// nullInt stores a zero int as NULL
+func nullInt(v int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(v), Valid: v != 0}
+}
+
+// nullTime stores a zero time as NULL
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// publishedKey is a publish date as ContentHash sees it
+func publishedKey(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// textArray stores a nil slice as an empty array, matching the column default
+func textArray(s []string) interface{} {
+	if s == nil {
+		s = []string{}
+	}
+	return pq.Array(s)
+}
+
+// JobTag is one row of job_tags
+type JobTag struct {
+	Tag    string
+	Origin string // "source" or "detected"
+}
+
 type JobRow struct {
 	ID, Source, Title, Company, Description, Location, WorkType, URL string
-	SalaryMin, SalaryMax                                             int
-	PublishedAt                                                      string // ISO-8601
-	Vector                                                           pq.Float32Array
-	FitScore                                                         *float32
+	// Sources set Description to what they were given, usually HTML; before
+	// storing it becomes plain text for embedding and DescriptionMarkdown the
+	// formatted rendering (utils.RenderDescription)
+	DescriptionMarkdown        string
+	SalaryMin, SalaryMax       int    // annual, in SalaryCurrency
+	SalaryCurrency             string // ISO 4217, empty when unknown
+	SalaryPeriod               string // pay interval the posting advertised
+	SalaryMinUSD, SalaryMaxUSD int    // annual US dollar equivalent, 0 when unknown
+	SalaryRaw                  string // the salary as the source gave it, before parsing
+	// Structured location, see internal/location. Sources that only list one
+	// kind of job set WorkplaceType up front as a hint.
+	WorkplaceType              string
+	Countries, Regions         []string // ISO country codes, region slugs
+	RemoteAnywhere             bool
+	Eligibility                []string // restrictions as written: "US only", "UTC±3"
+	UTCOffsetMin, UTCOffsetMax *float64
+	// CompanyLogo and CompanyURL come from the source when it has them;
+	// CompanyKey and CompanyDomain are derived by internal/company
+	CompanyLogo, CompanyURL   string
+	CompanyKey, CompanyDomain string
+	// Derived by internal/classify; WorkType keeps the source's raw value
+	Seniority, EmploymentType, RoleFamily string
+	// SourceTags are the source's own tags as given; Tags is what gets stored
+	// in job_tags after normalization and tech detection (internal/tags)
+	SourceTags []string
+	Tags       []JobTag
+	SimHash    uint64 // description fingerprint for duplicate detection
+	// PublishedAt is zero when the source gave no date or one internal/dates
+	// couldn't read; PublishedRaw keeps the source's value for reporting
+	PublishedAt  time.Time
+	PublishedRaw string
+	// Description split by topic, see internal/sections
+	Sections sections.Sections
+	// ContentHash fingerprints the raw posting, see ContentHash. It has to be
+	// taken before the description is rendered and the rest normalized.
+	ContentHash string
+	Vector      pq.Float32Array
+	FitScore    *float32
 }
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestContentHash(t *testing.T) {
+	raw := JobRow{
+		Title:       "Senior Go Engineer",
+		Company:     "Acme Inc",
+		Description: "<p>Build <b>things</b></p>",
+		Location:    "Remote, US",
+		SalaryRaw:   "$120k-$150k",
+		URL:         "https://example.com/jobs/1",
+		PublishedAt: time.Date(2026, 10, 10, 8, 30, 0, 0, time.UTC),
+	}
+	want := ContentHash(raw)
+
+	// Only derived fields change, e.g. after a renderer or normalizer update
+	derived := raw
+	derived.DescriptionMarkdown = "Build **things**"
+	derived.SalaryMin, derived.SalaryMax = 120000, 150000
+	derived.SalaryMinUSD, derived.SalaryMaxUSD = 120000, 150000
+	derived.SalaryCurrency, derived.SalaryPeriod = "USD", "yearly"
+	derived.SourceTags = []string{"golang"}
+	derived.Tags = []JobTag{{Tag: "go", Origin: "detected"}}
+	derived.Countries, derived.Regions = []string{"US"}, []string{"north-america"}
+	derived.Seniority, derived.RoleFamily = "senior", "backend"
+	derived.CompanyKey = "acme"
+	if got := ContentHash(derived); got != want {
+		t.Errorf("hash changed with derived fields only: %s, want %s", got, want)
+	}
+
+	// The same instant in another zone is the same publish date
+	derived.PublishedAt = raw.PublishedAt.In(time.FixedZone("IST", 5*3600+1800))
+	if got := ContentHash(derived); got != want {
+		t.Errorf("hash changed with the publish date's zone: %s, want %s", got, want)
+	}
+
+	for name, edit := range map[string]func(*JobRow){
+		"title":       func(r *JobRow) { r.Title = "Staff Go Engineer" },
+		"description": func(r *JobRow) { r.Description = "<p>Build more things</p>" },
+		"salary":      func(r *JobRow) { r.SalaryRaw = "$130k-$160k" },
+		"published":   func(r *JobRow) { r.PublishedAt = r.PublishedAt.Add(24 * time.Hour) },
+	} {
+		changed := raw
+		edit(&changed)
+		if ContentHash(changed) == want {
+			t.Errorf("hash unchanged after editing the %s", name)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// MarkListed records a complete fetch of a full-list source. Jobs with prefix
+// that the list returned are open again; the others count one more missed run
+// and are closed once they have missed missedRuns in a row. Returns how many
+// jobs were closed.
+func (s *Store) MarkListed(ctx context.Context, prefix string, ids []string, missedRuns int) (closed int64, err error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, `UPDATE jobs SET missed_runs = 0, closed_at = NULL
+		WHERE id = ANY($1) AND (missed_runs <> 0 OR closed_at IS NOT NULL)`, pq.Array(ids)); err != nil {
+		return 0, err
+	}
+
+	// left() instead of LIKE, so "_" in a prefix isn't a wildcard
+	if err = tx.QueryRowContext(ctx, `WITH missed AS (
+			UPDATE jobs SET missed_runs = missed_runs + 1,
+				closed_at = CASE WHEN missed_runs + 1 >= $3 THEN NOW() END
+			WHERE left(id, length($1)) = $1 AND closed_at IS NULL AND NOT (id = ANY($2))
+			RETURNING closed_at
+		)
+		SELECT COUNT(*) FILTER (WHERE closed_at IS NOT NULL) FROM missed`,
+		prefix, pq.Array(ids), missedRuns,
+	).Scan(&closed); err != nil {
+		return 0, err
+	}
+	return closed, tx.Commit()
+}
+
+// LivenessRow is an open job whose posting URL is due for a liveness check
+type LivenessRow struct {
+	ID, URL string
+}
+
+// FetchLivenessCandidates returns up to limit open jobs not checked within
+// interval, never-checked first. Jobs whose id starts with one of skipPrefixes
+// belong to full-list sources and are expired by MarkListed instead.
+func (s *Store) FetchLivenessCandidates(ctx context.Context, skipPrefixes []string, interval time.Duration, limit int) ([]LivenessRow, error) {
+	rows, err := s.DB.QueryContext(ctx, `SELECT id, url FROM jobs j
+		WHERE closed_at IS NULL AND url LIKE 'http%'
+		AND NOT EXISTS (SELECT 1 FROM unnest($1::text[]) AS p(prefix) WHERE left(j.id, length(p.prefix)) = p.prefix)
+		AND (checked_at IS NULL OR checked_at < NOW() - make_interval(secs => $2))
+		ORDER BY checked_at NULLS FIRST, published_at DESC
+		LIMIT $3`,
+		pq.Array(skipPrefixes), interval.Seconds(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []LivenessRow
+	for rows.Next() {
+		var r LivenessRow
+		if err := rows.Scan(&r.ID, &r.URL); err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+	return result, rows.Err()
+}
+
+// SaveLiveness records a liveness check of a job, closing it when its posting
+// is gone. Failed checks are saved as not closed, so they wait an interval too.
+func (s *Store) SaveLiveness(ctx context.Context, id string, closed bool) error {
+	_, err := s.DB.ExecContext(ctx, `UPDATE jobs SET checked_at = NOW(),
+		closed_at = CASE WHEN $2 THEN NOW() ELSE closed_at END
+		WHERE id = $1`, id, closed)
+	return err
+}
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+)
+
+// HTTPValidators are the ETag/Last-Modified values a source URL last returned
+type HTTPValidators struct {
+	ETag         string
+	LastModified string
+}
+
+// GetHTTPValidators returns the saved validators for url, empty if none are saved
+func (s *Store) GetHTTPValidators(ctx context.Context, url string) (HTTPValidators, error) {
+	var etag, lastModified sql.NullString
+	err := s.DB.QueryRowContext(ctx,
+		`SELECT etag, last_modified FROM http_cache WHERE url = $1`, url,
+	).Scan(&etag, &lastModified)
+	if err == sql.ErrNoRows {
+		return HTTPValidators{}, nil
+	}
+	if err != nil {
+		return HTTPValidators{}, err
+	}
+	return HTTPValidators{ETag: etag.String, LastModified: lastModified.String}, nil
+}
+
+// SaveHTTPValidators stores the validators url returned on its latest fetch
+func (s *Store) SaveHTTPValidators(ctx context.Context, url string, v HTTPValidators) error {
+	_, err := s.DB.ExecContext(ctx, `INSERT INTO http_cache (url, etag, last_modified, updated_at)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), NOW())
+		ON CONFLICT (url) DO UPDATE SET etag = EXCLUDED.etag, last_modified = EXCLUDED.last_modified, updated_at = NOW()`,
+		url, v.ETag, v.LastModified)
+	return err
+}
@@ -0,0 +1,250 @@
+package tags
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Tag origins
+const (
+	OriginSource   = "source"   // provided by the job source
+	OriginDetected = "detected" // found in the title or description
+)
+
+// Tag is a normalized tag and where it came from
+type Tag struct {
+	Name   string
+	Origin string
+}
+
+//go:embed taxonomy.yml
+var defaultTaxonomy []byte
+
+type taxonomyEntry struct {
+	Name          string   `yaml:"name"`
+	Aliases       []string `yaml:"aliases"`
+	CaseSensitive []string `yaml:"case_sensitive"`
+	TechContext   []string `yaml:"tech_context"`
+}
+
+type taxonomyFile struct {
+	Tags []taxonomyEntry `yaml:"tags"`
+}
+
+// Taxonomy maps technology names and their aliases to canonical tags
+type Taxonomy struct {
+	aliases       map[string]string // lower-cased alias -> canonical name
+	caseSensitive map[string]string // exact alias -> canonical name
+	techContext   map[string]string // exact alias only read in a tech context -> canonical name
+	anyCase       *regexp.Regexp
+	exactCase     *regexp.Regexp
+	inContext     *regexp.Regexp
+}
+
+// LoadTaxonomy reads a taxonomy YAML file, empty path uses the built-in one
+func LoadTaxonomy(path string) (*Taxonomy, error) {
+	buf := defaultTaxonomy
+	if path != "" {
+		var err error
+		if buf, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	var tf taxonomyFile
+	if err := yaml.Unmarshal(buf, &tf); err != nil {
+		return nil, fmt.Errorf("failed to parse tag taxonomy %s: %w", path, err)
+	}
+
+	t := &Taxonomy{
+		aliases:       make(map[string]string),
+		caseSensitive: make(map[string]string),
+		techContext:   make(map[string]string),
+	}
+	for i, e := range tf.Tags {
+		name := strings.ToLower(strings.TrimSpace(e.Name))
+		if name == "" {
+			return nil, fmt.Errorf("tag #%d in taxonomy %s: name is required", i+1, path)
+		}
+		if len(e.CaseSensitive) == 0 && len(e.TechContext) == 0 {
+			t.aliases[name] = name
+		}
+		for _, alias := range e.Aliases {
+			t.aliases[strings.ToLower(strings.TrimSpace(alias))] = name
+		}
+		for _, alias := range e.CaseSensitive {
+			t.caseSensitive[strings.TrimSpace(alias)] = name
+		}
+		for _, alias := range e.TechContext {
+			t.techContext[strings.TrimSpace(alias)] = name
+		}
+	}
+
+	t.anyCase = aliasRegex(t.aliases, "(?i)")
+	t.exactCase = aliasRegex(t.caseSensitive, "")
+	t.inContext = aliasRegex(t.techContext, "")
+	return t, nil
+}
+
+// aliasRegex matches any alias, longest first so "react native" wins over "react"
+func aliasRegex(aliases map[string]string, flags string) *regexp.Regexp {
+	if len(aliases) == 0 {
+		return nil
+	}
+	list := make([]string, 0, len(aliases))
+	for alias := range aliases {
+		list = append(list, regexp.QuoteMeta(alias))
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if len(list[i]) != len(list[j]) {
+			return len(list[i]) > len(list[j])
+		}
+		return list[i] < list[j]
+	})
+	return regexp.MustCompile(flags + `(?:` + strings.Join(list, "|") + `)`)
+}
+
+// Normalize maps a source tag to its canonical name; tags outside the
+// taxonomy are kept, lower-cased
+func (t *Taxonomy) Normalize(tag string) string {
+	tag = strings.Join(strings.Fields(tag), " ")
+	if name, ok := t.caseSensitive[tag]; ok {
+		return name
+	}
+	if name, ok := t.techContext[tag]; ok {
+		return name
+	}
+	lower := strings.ToLower(tag)
+	if name, ok := t.aliases[lower]; ok {
+		return name
+	}
+	return lower
+}
+
+// Detect returns the canonical names of the technologies mentioned in text
+func (t *Taxonomy) Detect(text string) []string {
+	var found []string
+	for _, re := range []*regexp.Regexp{t.anyCase, t.exactCase, t.inContext} {
+		if re == nil {
+			continue
+		}
+		for _, idx := range re.FindAllStringIndex(text, -1) {
+			if !isWord(text, idx[0], idx[1]) {
+				continue
+			}
+			match := text[idx[0]:idx[1]]
+			name, ok := t.caseSensitive[match]
+			if !ok && re == t.inContext {
+				if !inTechContext(text, idx[0], idx[1]) {
+					continue
+				}
+				name, ok = t.techContext[match]
+			}
+			if !ok {
+				name = t.aliases[strings.ToLower(match)]
+			}
+			found = appendUnique(found, name)
+		}
+	}
+	return found
+}
+
+var (
+	listItemRegex   = regexp.MustCompile(`^\s*(?:[-+*•]|\d+[.)])\s`)
+	stackSepBefore  = regexp.MustCompile(`(?:[,/|&(]|\band|\bor)\s*$`)
+	stackSepAfter   = regexp.MustCompile(`^\s*[,/|&)]|^\s+(?:and|or)\s+\p{Lu}`)
+	lowerWordAfter  = regexp.MustCompile(`^\s+\p{Ll}`)
+	roleWordAfter   = regexp.MustCompile(`(?i)^\s+(?:developers?|engineers?|programmers?|backend|microservices|services|stack|code|modules?)\b`)
+	golangNearRegex = regexp.MustCompile(`(?i)^\s*[(/]?\s*golang\b`)
+)
+
+// inTechContext reports whether an alias that is also an English word, like
+// "Go", is used as a technology: in a comma-separated or slashed stack list
+// ("Go, Rust", "Go/Python"), next to "Golang", before a word such as
+// "Engineer" or "services", or alone in a list item ("- Go", "* Go 1.22").
+// "Go beyond", "Go-to-market" and "Go live" are not.
+func inTechContext(text string, start, end int) bool {
+	if end < len(text) && text[end] == '-' {
+		return false
+	}
+	lineStart := strings.LastIndexByte(text[:start], '\n') + 1
+	lineEnd := len(text)
+	if i := strings.IndexByte(text[end:], '\n'); i >= 0 {
+		lineEnd = end + i
+	}
+	before, after := text[lineStart:start], text[end:lineEnd]
+
+	switch {
+	case golangNearRegex.MatchString(after):
+		return true
+	case roleWordAfter.MatchString(after):
+		return true
+	case stackSepAfter.MatchString(after):
+		return true
+	case lowerWordAfter.MatchString(after):
+		// "Go beyond", "Go and see", "Go live" read as verbs
+		return false
+	case stackSepBefore.MatchString(before):
+		return true
+	case listItemRegex.MatchString(before) && strings.TrimSpace(listItemRegex.ReplaceAllString(before, "")) == "":
+		return true
+	}
+	return false
+}
+
+// isWord reports whether text[start:end] stands alone: "go" in "Go," but not in
+// "good", "java" in "Java" but not in "javascript", "c++" before a space
+func isWord(text string, start, end int) bool {
+	if start > 0 {
+		r, _ := utf8.DecodeLastRuneInString(text[:start])
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return false
+		}
+	}
+	if end < len(text) {
+		r, _ := utf8.DecodeRuneInString(text[end:])
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' || r == '#' {
+			return false
+		}
+	}
+	return true
+}
+
+// Extract merges the source's own tags with technologies detected in the title
+// and description. Source tags keep OriginSource even when also detected.
+func (t *Taxonomy) Extract(sourceTags []string, title, description string) []Tag {
+	var out []Tag
+	seen := make(map[string]bool)
+	add := func(name, origin string) {
+		if name == "" || seen[name] {
+			return
+		}
+		seen[name] = true
+		out = append(out, Tag{Name: name, Origin: origin})
+	}
+
+	for _, tag := range sourceTags {
+		add(t.Normalize(tag), OriginSource)
+	}
+	for _, name := range t.Detect(title + "\n" + description) {
+		add(name, OriginDetected)
+	}
+	return out
+}
+
+func appendUnique(list []string, item string) []string {
+	for _, existing := range list {
+		if existing == item {
+			return list
+		}
+	}
+	return append(list, item)
+}
@@ -0,0 +1,105 @@
+package tags
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func defaultTaxonomyForTest(t *testing.T) *Taxonomy {
+	t.Helper()
+	tx, err := LoadTaxonomy("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+func TestDetectGo(t *testing.T) {
+	tx := defaultTaxonomyForTest(t)
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"Senior Go Engineer", true},
+		{"Backend Engineer (Go)", true},
+		{"Our stack: Go, PostgreSQL, Kafka", true},
+		{"Python/Go", true},
+		{"Rust and Go", true},
+		{"- Go\n- Rust", true},
+		{"* Go 1.22", true},
+		{"Go (Golang) microservices", true},
+		{"golang", true},
+		{"Go beyond what's expected.", false},
+		{"- Go beyond the call of duty", false},
+		{"Go and see the world", false},
+		{"Our Go-to-market team", false},
+		{"Ready to Go? Apply now!", false},
+		{"good governance", false},
+	}
+	for _, tt := range tests {
+		got := false
+		for _, name := range tx.Detect(tt.text) {
+			if name == "go" {
+				got = true
+			}
+		}
+		if got != tt.want {
+			t.Errorf("Detect(%q) found go = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tx := defaultTaxonomyForTest(t)
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"React Native and React", []string{"react", "react native"}},
+		{"k8s, ECMAScript", []string{"javascript", "kubernetes"}},
+		{"C++ and C# experience", []string{"c#", "c++"}},
+		{"Java, not JavaScript", []string{"java", "javascript"}},
+		{"Swift UI for iOS", []string{"ios", "swift"}},
+		{"a swift response", nil},
+	}
+	for _, tt := range tests {
+		got := tx.Detect(tt.text)
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Detect(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tx := defaultTaxonomyForTest(t)
+	got := tx.Extract([]string{"Golang", "  Remote  Work ", "go"}, "Senior Go Engineer", "Kubernetes and PostgreSQL.")
+	want := []Tag{
+		{Name: "go", Origin: OriginSource},
+		{Name: "remote work", Origin: OriginSource},
+		{Name: "kubernetes", Origin: OriginDetected},
+		{Name: "postgresql", Origin: OriginDetected},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Extract = %+v, want %+v", got, want)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tx := defaultTaxonomyForTest(t)
+	for in, want := range map[string]string{
+		"Golang":      "go",
+		"Go":          "go",
+		"K8s":         "kubernetes",
+		"Swift":       "swift",
+		"Data  Mesh":  "data mesh",
+		"JavaScript":  "javascript",
+		"Postgres":    "postgresql",
+		"Not A Thing": "not a thing",
+	} {
+		if got := tx.Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
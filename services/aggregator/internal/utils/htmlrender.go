@@ -0,0 +1,672 @@
+package utils
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// Elements whose content is never part of the description
+var skippedElements = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true, "head": true,
+	"title": true, "svg": true, "iframe": true, "object": true, "embed": true,
+	"canvas": true, "button": true, "select": true, "form": true,
+}
+
+// Elements that start a paragraph of their own
+var blockElements = map[string]bool{
+	"p": true, "div": true, "section": true, "article": true, "header": true,
+	"footer": true, "main": true, "aside": true, "nav": true, "blockquote": true,
+	"address": true, "figure": true, "figcaption": true, "dl": true, "dt": true,
+	"dd": true, "details": true, "summary": true, "center": true, "body": true,
+}
+
+// Characters sources use as hand-made bullets at the start of a line
+var bulletChars = "•·▪▫◦‣●○■□►▸-–—*"
+
+var (
+	orderedMarkerRegex = regexp.MustCompile(`^\d+[.)]$`)
+	hiddenStyleRegex   = regexp.MustCompile(`(?i)display\s*:\s*none|visibility\s*:\s*hidden`)
+	pixelStyleRegex    = regexp.MustCompile(`(?i)(^|[;\s])(width|height)\s*:\s*[01](px)?\s*(;|$)`)
+	markdownEscaper    = strings.NewReplacer(
+		`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`,
+	)
+)
+
+// Line break strengths, the strongest pending one wins
+const (
+	breakNone = iota
+	breakLine
+	breakHard // <br>, a line break inside a paragraph
+	breakParagraph
+)
+
+type listLevel struct {
+	ordered bool
+	next    int // number of the next ordered item
+	indent  int // width of the current item's marker
+}
+
+// capture buffers inline content (a link's text, an emphasized run, a table
+// cell) so it can be trimmed and wrapped once it's complete
+type capture struct {
+	b     strings.Builder
+	tag   string
+	href  string
+	raw   bool // code: written verbatim, not escaped
+	space bool // whitespace pending inside the capture
+}
+
+type tableState struct {
+	rows [][]string
+}
+
+// htmlRenderer turns a stream of HTML tokens into Markdown or, with markdown
+// unset, plain text with the same line structure
+type htmlRenderer struct {
+	markdown bool
+	out      strings.Builder
+
+	skipTag   string // element being skipped, with its nesting depth
+	skipDepth int
+
+	pending    int  // strongest break requested since the last text
+	space      bool // whitespace pending before the next word
+	started    bool // something was written
+	lineStart  bool
+	linePrefix string // heading marker for the next line
+	lists      []listLevel
+	marker     string // list marker for the next line
+	captures   []*capture
+	table      *tableState
+	tableDepth int
+	pre        int
+	preBuf     strings.Builder
+}
+
+func renderHTML(src string, markdown bool) string {
+	r := &htmlRenderer{markdown: markdown, lineStart: true}
+	z := html.NewTokenizer(strings.NewReader(src))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break // io.EOF, or input the tokenizer can't go on with
+		}
+		tok := z.Token()
+		switch tt {
+		case html.TextToken:
+			r.text(tok.Data)
+		case html.StartTagToken:
+			r.start(tok, false)
+		case html.SelfClosingTagToken:
+			r.start(tok, true)
+		case html.EndTagToken:
+			r.end(tok.Data)
+		}
+	}
+	// Close whatever the source left open
+	for len(r.captures) > 0 {
+		r.end(r.captures[len(r.captures)-1].tag)
+	}
+	if r.pre > 0 {
+		r.pre = 1
+		r.end("pre")
+	}
+	if r.table != nil {
+		r.tableDepth = 1
+		r.end("table")
+	}
+	return cleanLines(r.out.String())
+}
+
+// cleanLines drops trailing whitespace and runs of blank lines
+func cleanLines(s string) string {
+	lines := strings.Split(s, "\n")
+	out := lines[:0]
+	blank := 0
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t ")
+		if line == "" {
+			blank++
+			if blank > 1 {
+				continue
+			}
+		} else {
+			blank = 0
+		}
+		out = append(out, line)
+	}
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+func attr(tok html.Token, name string) string {
+	for _, a := range tok.Attr {
+		if a.Key == name {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func hasAttr(tok html.Token, name string) bool {
+	for _, a := range tok.Attr {
+		if a.Key == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hidden reports elements marked not to be displayed
+func hidden(tok html.Token) bool {
+	return hasAttr(tok, "hidden") || attr(tok, "aria-hidden") == "true" ||
+		hiddenStyleRegex.MatchString(attr(tok, "style"))
+}
+
+// trackingPixel reports images that only exist to record a view
+func trackingPixel(tok html.Token) bool {
+	for _, dim := range []string{"width", "height"} {
+		if v := strings.TrimSuffix(strings.TrimSpace(attr(tok, dim)), "px"); v == "0" || v == "1" {
+			return true
+		}
+	}
+	return pixelStyleRegex.MatchString(attr(tok, "style"))
+}
+
+// safeURL keeps absolute http(s) and mailto links, escaped for a Markdown destination
+func safeURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "mailto") {
+		return ""
+	}
+	if u.Scheme != "mailto" && u.Host == "" {
+		return ""
+	}
+	return strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29").Replace(u.String())
+}
+
+func (r *htmlRenderer) requestBreak(level int) {
+	if len(r.captures) > 0 {
+		r.markSpace()
+		return
+	}
+	// Paragraphs inside list items stay in the item
+	if len(r.lists) > 0 && level == breakParagraph {
+		level = breakHard
+	}
+	if level > r.pending {
+		r.pending = level
+	}
+}
+
+// write emits already escaped text, flushing pending breaks, list markers
+// and spaces before it
+func (r *htmlRenderer) write(s string) {
+	if s == "" {
+		return
+	}
+	if n := len(r.captures); n > 0 {
+		c := r.captures[n-1]
+		if c.space && c.b.Len() > 0 {
+			c.b.WriteByte(' ')
+		}
+		c.space = false
+		c.b.WriteString(s)
+		return
+	}
+
+	if r.started && r.pending != breakNone {
+		pending := r.pending
+		if pending == breakHard && r.marker != "" {
+			pending = breakLine // a new list item needs no hard break
+		}
+		switch pending {
+		case breakLine:
+			r.out.WriteString("\n")
+		case breakHard:
+			if r.markdown {
+				r.out.WriteString("\\\n")
+			} else {
+				r.out.WriteString("\n")
+			}
+		case breakParagraph:
+			r.out.WriteString("\n\n")
+		}
+		r.lineStart = true
+	}
+	r.pending = breakNone
+
+	if r.lineStart {
+		r.writeLinePrefix()
+	} else if r.space {
+		r.out.WriteByte(' ')
+	}
+	r.space = false
+	r.lineStart = false
+	r.started = true
+	r.out.WriteString(s)
+}
+
+// writeLinePrefix indents a line into its list item and writes any pending
+// list or heading marker
+func (r *htmlRenderer) writeLinePrefix() {
+	if !r.markdown {
+		r.marker, r.linePrefix = "", ""
+		return
+	}
+	indent := 0
+	for i, l := range r.lists {
+		if i == len(r.lists)-1 && r.marker != "" {
+			break
+		}
+		indent += l.indent
+	}
+	r.out.WriteString(strings.Repeat(" ", indent))
+	r.out.WriteString(r.marker)
+	r.out.WriteString(r.linePrefix)
+	r.marker, r.linePrefix = "", ""
+}
+
+func (r *htmlRenderer) text(data string) {
+	if r.skipDepth > 0 {
+		return
+	}
+	if r.pre > 0 {
+		r.preBuf.WriteString(data)
+		return
+	}
+
+	words := strings.Fields(data)
+	if first, _ := utf8.DecodeRuneInString(data); unicode.IsSpace(first) {
+		r.markSpace()
+	}
+	for i, w := range words {
+		if i > 0 {
+			r.markSpace()
+		}
+		r.word(w)
+	}
+	if last, _ := utf8.DecodeLastRuneInString(data); len(words) > 0 && unicode.IsSpace(last) {
+		r.markSpace()
+	}
+}
+
+// markSpace records whitespace before the next word. Whitespace at the start
+// of a capture belongs outside it: "a<b> b</b>" is "a **b**".
+func (r *htmlRenderer) markSpace() {
+	for i := len(r.captures) - 1; i >= 0; i-- {
+		if r.captures[i].b.Len() > 0 {
+			r.captures[i].space = true
+			return
+		}
+	}
+	if r.started {
+		r.space = true
+	}
+}
+
+// word writes one word of text, turning a hand-made bullet at the start of a
+// line into a list item and escaping Markdown syntax
+func (r *htmlRenderer) word(w string) {
+	atLineStart := len(r.captures) == 0 && (r.lineStart || r.pending != breakNone || !r.started)
+	if atLineStart && r.marker == "" && r.linePrefix == "" {
+		if first, rest := splitBullet(w); first {
+			if r.markdown {
+				r.marker = "- "
+			}
+			r.requestBreak(breakLine)
+			if rest == "" {
+				return
+			}
+			w = rest
+		}
+	}
+
+	if n := len(r.captures); !r.markdown || (n > 0 && r.captures[n-1].raw) {
+		r.write(w)
+		return
+	}
+	escaped := markdownEscaper.Replace(w)
+	if atLineStart {
+		switch {
+		case strings.HasPrefix(w, "#"), strings.HasPrefix(w, ">"),
+			w == "-", w == "+":
+			escaped = `\` + escaped
+		case orderedMarkerRegex.MatchString(w):
+			escaped = escaped[:len(escaped)-1] + `\` + escaped[len(escaped)-1:]
+		}
+	}
+	r.write(escaped)
+}
+
+// splitBullet reports whether w starts with a bullet character and returns
+// what follows it
+func splitBullet(w string) (bool, string) {
+	for _, b := range bulletChars {
+		s := string(b)
+		if w == s {
+			return true, ""
+		}
+		// "-5%" or "*required" aren't bullets, "•Go" is
+		if strings.HasPrefix(w, s) && !strings.ContainsRune("-*", b) {
+			return true, strings.TrimPrefix(w, s)
+		}
+	}
+	return false, ""
+}
+
+func (r *htmlRenderer) start(tok html.Token, selfClosing bool) {
+	name := tok.Data
+	if r.skipDepth > 0 {
+		if name == r.skipTag && !selfClosing {
+			r.skipDepth++
+		}
+		return
+	}
+	if skippedElements[name] || hidden(tok) {
+		if !selfClosing && !isVoid(name) {
+			r.skipTag, r.skipDepth = name, 1
+		}
+		return
+	}
+	if r.pre > 0 {
+		if name == "pre" {
+			r.pre++
+		} else if name == "br" {
+			r.preBuf.WriteString("\n")
+		}
+		return
+	}
+
+	switch name {
+	case "br":
+		if r.pending == breakHard {
+			r.requestBreak(breakParagraph)
+		} else {
+			r.requestBreak(breakHard)
+		}
+	case "hr":
+		r.requestBreak(breakParagraph)
+		if r.markdown && len(r.captures) == 0 && len(r.lists) == 0 {
+			r.write("---")
+			r.requestBreak(breakParagraph)
+		}
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		r.requestBreak(breakParagraph)
+		if r.markdown && len(r.captures) == 0 {
+			level, _ := strconv.Atoi(name[1:])
+			r.linePrefix = strings.Repeat("#", level) + " "
+		}
+	case "ul", "ol":
+		if len(r.lists) == 0 {
+			r.requestBreak(breakParagraph)
+		} else {
+			r.requestBreak(breakLine)
+		}
+		start := 1
+		if n, err := strconv.Atoi(attr(tok, "start")); err == nil {
+			start = n
+		}
+		r.lists = append(r.lists, listLevel{ordered: name == "ol", next: start})
+	case "li":
+		r.requestBreak(breakLine)
+		if len(r.captures) > 0 {
+			return
+		}
+		if len(r.lists) == 0 {
+			r.lists = append(r.lists, listLevel{}) // stray <li>
+		}
+		l := &r.lists[len(r.lists)-1]
+		marker := "- "
+		if l.ordered {
+			marker = strconv.Itoa(l.next) + ". "
+			l.next++
+		}
+		l.indent = len(marker)
+		if r.markdown {
+			r.marker = marker
+		}
+		r.linePrefix = ""
+	case "pre":
+		r.requestBreak(breakParagraph)
+		r.pre = 1
+		r.preBuf.Reset()
+	case "a":
+		r.push(&capture{tag: name, href: safeURL(attr(tok, "href"))})
+	case "strong", "b", "em", "i", "code", "tt", "kbd", "samp":
+		r.push(&capture{tag: name, raw: name == "code" || name == "tt" || name == "kbd" || name == "samp"})
+	case "img":
+		if r.markdown && !trackingPixel(tok) {
+			if src := safeURL(attr(tok, "src")); src != "" {
+				r.write("![" + markdownEscaper.Replace(strings.TrimSpace(attr(tok, "alt"))) + "](" + src + ")")
+			}
+		}
+	case "table":
+		r.tableDepth++
+		if r.tableDepth == 1 {
+			r.requestBreak(breakParagraph)
+			r.table = &tableState{}
+		}
+	case "tr":
+		if r.tableDepth == 1 {
+			r.table.rows = append(r.table.rows, nil)
+		}
+	case "td", "th":
+		if r.tableDepth == 1 && len(r.captures) == 0 {
+			if len(r.table.rows) == 0 {
+				r.table.rows = append(r.table.rows, nil) // cell outside any <tr>
+			}
+			r.push(&capture{tag: "td"})
+		} else {
+			r.markSpace()
+		}
+	default:
+		if blockElements[name] {
+			r.requestBreak(breakParagraph)
+		}
+	}
+}
+
+func isVoid(name string) bool {
+	switch name {
+	case "area", "base", "br", "col", "embed", "hr", "img", "input", "link", "meta", "source", "track", "wbr":
+		return true
+	}
+	return false
+}
+
+func (r *htmlRenderer) push(c *capture) {
+	r.captures = append(r.captures, c)
+}
+
+func (r *htmlRenderer) end(name string) {
+	if r.skipDepth > 0 {
+		if name == r.skipTag {
+			r.skipDepth--
+		}
+		return
+	}
+	if r.pre > 0 {
+		if name != "pre" {
+			return
+		}
+		if r.pre--; r.pre > 0 {
+			return
+		}
+		r.writePre(r.preBuf.String())
+		r.requestBreak(breakParagraph)
+		return
+	}
+
+	switch name {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		r.linePrefix = ""
+		r.requestBreak(breakParagraph)
+	case "ul", "ol":
+		if len(r.lists) > 0 {
+			r.lists = r.lists[:len(r.lists)-1]
+		}
+		r.marker = ""
+		if len(r.lists) == 0 {
+			r.requestBreak(breakParagraph)
+		} else {
+			r.requestBreak(breakLine)
+		}
+	case "li":
+		r.requestBreak(breakLine)
+	case "a", "strong", "b", "em", "i", "code", "tt", "kbd", "samp":
+		r.closeCapture(name)
+	case "td", "th":
+		if r.tableDepth == 1 {
+			r.closeCapture("td")
+		}
+	case "table":
+		if r.tableDepth--; r.tableDepth == 0 && r.table != nil {
+			for len(r.captures) > 0 {
+				r.closeCapture(r.captures[len(r.captures)-1].tag)
+			}
+			r.writeTable(r.table.rows)
+			r.table = nil
+			r.requestBreak(breakParagraph)
+		}
+	default:
+		if blockElements[name] {
+			r.requestBreak(breakParagraph)
+		}
+	}
+}
+
+// closeCapture pops the innermost capture opened by tag, closing anything the
+// source left open inside it
+func (r *htmlRenderer) closeCapture(tag string) {
+	equivalent := func(t string) bool {
+		return t == tag || (tag == "b" && t == "strong") || (tag == "strong" && t == "b") ||
+			(tag == "i" && t == "em") || (tag == "em" && t == "i")
+	}
+	open := false
+	for _, c := range r.captures {
+		if equivalent(c.tag) {
+			open = true
+		}
+	}
+	if !open {
+		return
+	}
+
+	for len(r.captures) > 0 {
+		c := r.captures[len(r.captures)-1]
+		r.captures = r.captures[:len(r.captures)-1]
+		inner := strings.TrimSpace(c.b.String())
+		trailing := c.space
+
+		switch {
+		case c.tag == "td":
+			if r.table != nil && len(r.table.rows) > 0 {
+				last := len(r.table.rows) - 1
+				r.table.rows[last] = append(r.table.rows[last], inner)
+			}
+		case inner == "":
+		case !r.markdown:
+			r.write(inner)
+		case c.tag == "a":
+			if c.href != "" {
+				r.write("[" + inner + "](" + c.href + ")")
+			} else {
+				r.write(inner)
+			}
+		case c.tag == "strong" || c.tag == "b":
+			r.write("**" + inner + "**")
+		case c.tag == "em" || c.tag == "i":
+			r.write("_" + inner + "_")
+		default:
+			fence := strings.Repeat("`", longestRun(inner, '`')+1)
+			if strings.HasPrefix(inner, "`") || strings.HasSuffix(inner, "`") {
+				inner = " " + inner + " "
+			}
+			r.write(fence + inner + fence)
+		}
+		if trailing {
+			r.markSpace()
+		}
+		if equivalent(c.tag) {
+			return
+		}
+	}
+}
+
+func longestRun(s string, ch byte) int {
+	longest, run := 0, 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == ch {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return longest
+}
+
+// writePre writes preformatted text as is, fenced in Markdown
+func (r *htmlRenderer) writePre(text string) {
+	text = strings.Trim(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+	if !r.markdown {
+		r.write(text)
+		return
+	}
+	fence := strings.Repeat("`", max(3, longestRun(text, '`')+1))
+	r.write(fence + "\n" + text + "\n" + fence)
+}
+
+// writeTable writes a Markdown table with the first row as its header. Plain
+// text and single-column layout tables get a line per cell instead.
+func (r *htmlRenderer) writeTable(rows [][]string) {
+	cols := 0
+	for _, row := range rows {
+		cols = max(cols, len(row))
+	}
+	if cols == 0 {
+		return
+	}
+
+	if !r.markdown || cols == 1 {
+		for _, row := range rows {
+			var cells []string
+			for _, cell := range row {
+				if cell != "" {
+					cells = append(cells, cell)
+				}
+			}
+			if len(cells) > 0 {
+				r.requestBreak(breakLine)
+				r.write(strings.Join(cells, " | "))
+			}
+		}
+		return
+	}
+
+	line := func(cells []string) string {
+		padded := make([]string, cols)
+		for i := range padded {
+			if i < len(cells) {
+				padded[i] = strings.ReplaceAll(cells[i], "|", `\|`)
+			}
+		}
+		return "| " + strings.Join(padded, " | ") + " |"
+	}
+	r.write(line(rows[0]))
+	r.requestBreak(breakLine)
+	r.write("|" + strings.Repeat(" --- |", cols))
+	for _, row := range rows[1:] {
+		r.requestBreak(breakLine)
+		r.write(line(row))
+	}
+}
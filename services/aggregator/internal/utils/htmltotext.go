@@ -1,52 +1,43 @@
 package utils
 
 import (
-	"html"
 	"regexp"
 	"strings"
 )
 
 var (
 	htmlDetectionRegex  = regexp.MustCompile(`<[a-zA-Z][^>]*>`)
-	blockElements       = regexp.MustCompile(`(?i)</(div|p|br|h[1-6]|li|tr)>`)
-	listItems           = regexp.MustCompile(`(?i)<li[^>]*>`)
-	spanTags            = regexp.MustCompile(`(?i)<span[^>]*>`)
-	htmlTagRegex        = regexp.MustCompile(`<[^>]*>`)
-	whitespaceRegex     = regexp.MustCompile(`[ \t]+`)
-	lineBreakRegex      = regexp.MustCompile(`\n\s*\n`)
 	escapedNewlineRegex = regexp.MustCompile(`\\n`)
 )
 
-// ConvertHTMLToText converts HTML content to plain text
+// ConvertHTMLToText converts HTML content to plain text: one line per
+// paragraph, heading, list item and table row, without markup. Scripts,
+// styles, hidden elements and images are dropped.
 func ConvertHTMLToText(htmlContent string) string {
-	// First, unescape HTML entities
-	text := html.UnescapeString(htmlContent)
-
-	// Handle escaped newlines (like \\n in RemoteOK descriptions)
-	text = escapedNewlineRegex.ReplaceAllString(text, "\n")
-
-	// Replace common block elements with line breaks for better readability
-	text = blockElements.ReplaceAllString(text, "\n")
-
-	// Replace list items with bullet points
-	text = listItems.ReplaceAllString(text, "\n• ")
-
-	// Handle span tags (often contain styling info, just remove them)
-	text = spanTags.ReplaceAllString(text, "")
-
-	// Remove all remaining HTML tags
-	text = htmlTagRegex.ReplaceAllString(text, " ")
-
-	// Clean up multiple whitespace characters but preserve line breaks
-	text = whitespaceRegex.ReplaceAllString(text, " ")
+	return renderHTML(unescapeNewlines(htmlContent), false)
+}
 
-	// Clean up multiple line breaks
-	text = lineBreakRegex.ReplaceAllString(text, "\n")
+// ConvertHTMLToMarkdown converts HTML content to Markdown, keeping headings,
+// nested lists, links, emphasis, code and tables. Scripts, styles, hidden
+// elements and tracking pixels are dropped, as are links that aren't
+// http(s) or mailto.
+func ConvertHTMLToMarkdown(htmlContent string) string {
+	return renderHTML(unescapeNewlines(htmlContent), true)
+}
 
-	// Remove leading/trailing whitespace
-	text = strings.TrimSpace(text)
+// RenderDescription returns a job description as Markdown and as plain text.
+// Descriptions without HTML are returned as they are in both.
+func RenderDescription(description string) (markdown, text string) {
+	if !htmlDetectionRegex.MatchString(description) {
+		description = strings.TrimSpace(unescapeNewlines(description))
+		return description, description
+	}
+	return ConvertHTMLToMarkdown(description), ConvertHTMLToText(description)
+}
 
-	return text
+// unescapeNewlines handles escaped newlines (like \\n in RemoteOK descriptions)
+func unescapeNewlines(s string) string {
+	return escapedNewlineRegex.ReplaceAllString(s, "\n")
 }
 
 // PreprocessText cleans and prepares text for embedding or storage
@@ -0,0 +1,85 @@
+package utils
+
+import "testing"
+
+func TestRenderDescription(t *testing.T) {
+	tests := []struct {
+		name           string
+		in             string
+		markdown, text string
+	}{
+		{
+			name:     "heading, paragraph and emphasis",
+			in:       "<h2>About us</h2><p>We build <strong>payments</strong> &amp; <em>billing</em>.</p>",
+			markdown: "## About us\n\nWe build **payments** & _billing_.",
+			text:     "About us\n\nWe build payments & billing.",
+		},
+		{
+			name:     "nested list",
+			in:       "<ul><li>Go</li><li>Postgres<ul><li>pgx</li></ul></li></ul>",
+			markdown: "- Go\n- Postgres\n  - pgx",
+			text:     "Go\nPostgres\npgx",
+		},
+		{
+			name:     "ordered list",
+			in:       "<ol><li>One</li><li>Two</li></ol>",
+			markdown: "1. One\n2. Two",
+			text:     "One\nTwo",
+		},
+		{
+			name:     "unsafe links, tracking pixels and scripts dropped",
+			in:       `<p>Apply <a href="https://acme.example/jobs?id=1">here</a> or <a href="javascript:alert(1)">there</a>.</p><img src="https://t.example/pixel.gif" width="1" height="1"><script>track()</script>`,
+			markdown: "Apply [here](https://acme.example/jobs?id=1) or there.",
+			text:     "Apply here or there.",
+		},
+		{
+			name:     "inline code and preformatted text",
+			in:       "<p>Use <code>go test</code></p><pre>line 1\n  line 2</pre>",
+			markdown: "Use `go test`\n\n```\nline 1\n  line 2\n```",
+			text:     "Use go test\n\nline 1\n  line 2",
+		},
+		{
+			name:     "table",
+			in:       "<table><tr><th>Level</th><th>Pay</th></tr><tr><td>Senior</td><td>$150k</td></tr></table>",
+			markdown: "| Level | Pay |\n| --- | --- |\n| Senior | $150k |",
+			text:     "Level | Pay\nSenior | $150k",
+		},
+		{
+			name:     "hidden elements dropped, Markdown characters escaped",
+			in:       `<div style="display:none">hidden</div><p>1*2 = 2</p>`,
+			markdown: `1\*2 = 2`,
+			text:     "1*2 = 2",
+		},
+		{
+			name:     "plain text with escaped newlines",
+			in:       `  Plain text\nwith escaped newline `,
+			markdown: "Plain text\nwith escaped newline",
+			text:     "Plain text\nwith escaped newline",
+		},
+		{
+			name: "empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markdown, text := RenderDescription(tt.in)
+			if markdown != tt.markdown {
+				t.Errorf("markdown = %q, want %q", markdown, tt.markdown)
+			}
+			if text != tt.text {
+				t.Errorf("text = %q, want %q", text, tt.text)
+			}
+		})
+	}
+}
+
+func TestPreprocessText(t *testing.T) {
+	text, wasHTML := PreprocessText("<p>Hello <b>world</b></p>", 0)
+	if text != "Hello world" || !wasHTML {
+		t.Errorf("PreprocessText = %q, %v", text, wasHTML)
+	}
+	text, wasHTML = PreprocessText("plain text that is long", 5)
+	if text != "plain" || wasHTML {
+		t.Errorf("PreprocessText truncated = %q, %v", text, wasHTML)
+	}
+}